@@ -5,12 +5,12 @@ go 1.23.2
 require (
 	github.com/fatih/color v1.18.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/sys v0.25.0
 )
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.25.0 // indirect
 )
 
 require (
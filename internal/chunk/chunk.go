@@ -0,0 +1,113 @@
+package chunk
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	// MinSize is the minimum size of a chunk, except for the last chunk of a stream.
+	MinSize = 2 << 10
+	// AvgSize is the targeted average size of a chunk.
+	AvgSize = 8 << 10
+	// MaxSize is the maximum size of a chunk.
+	MaxSize = 64 << 10
+)
+
+// Masks for normalized chunking: below the average size a boundary is harder to hit
+// (more bits) and above it easier (fewer bits), which narrows the size distribution.
+const (
+	maskHard uint64 = 0xfffe000000000000 // 15 bits
+	maskEasy uint64 = 0xffe0000000000000 // 11 bits
+)
+
+var gear = func() [256]uint64 {
+	var table [256]uint64
+
+	// splitmix64 with a fixed seed, so chunk boundaries are stable across runs.
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+
+	return table
+}()
+
+// Split reads r to the end and splits its content into chunks using a gear based rolling
+// hash (FastCDC), so boundaries depend on the content and survive insertions and deletions.
+// Each chunk is passed to fn, the slice is only valid until fn returns.
+//
+// Parameters:
+//   - r: The reader to split.
+//   - fn: Called for every chunk in order.
+//
+// Returns:
+//   - error: An error if reading fails or fn returns one.
+func Split(r io.Reader, fn func(chunk []byte) error) error {
+	buf := make([]byte, 2*MaxSize)
+	start, end := 0, 0
+	eof := false
+
+	for {
+		if !eof && end-start < MaxSize {
+			copy(buf, buf[start:end])
+			end -= start
+			start = 0
+
+			n, err := io.ReadFull(r, buf[end:])
+			end += n
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				eof = true
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if start == end {
+			return nil
+		}
+
+		size := boundary(buf[start:end])
+		if err := fn(buf[start : start+size]); err != nil {
+			return err
+		}
+		start += size
+	}
+}
+
+// boundary returns the length of the next chunk at the beginning of data.
+func boundary(data []byte) int {
+	n := len(data)
+	if n <= MinSize {
+		return n
+	}
+	if n > MaxSize {
+		n = MaxSize
+	}
+
+	normal := AvgSize
+	if n < normal {
+		normal = n
+	}
+
+	var hash uint64
+	i := MinSize
+	for ; i < normal; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&maskHard == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&maskEasy == 0 {
+			return i + 1
+		}
+	}
+
+	return n
+}
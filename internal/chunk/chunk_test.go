@@ -0,0 +1,82 @@
+package chunk
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomData(seed int64, size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+
+	return data
+}
+
+func split(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+
+	chunks := [][]byte{}
+	err := Split(bytes.NewReader(data), func(chunk []byte) error {
+		chunks = append(chunks, append([]byte{}, chunk...))
+		return nil
+	})
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	return chunks
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "Empty", size: 0},
+		{name: "Smaller than minimum", size: MinSize - 1},
+		{name: "Several chunks", size: 1 << 20},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := randomData(1, tt.size)
+			chunks := split(t, data)
+
+			assert.Equal(t, data, bytes.Join(chunks, nil), "Chunks do not add up to the input")
+			for i, chunk := range chunks {
+				assert.LessOrEqual(t, len(chunk), MaxSize, "Chunk exceeds the maximum size")
+				if i < len(chunks)-1 {
+					assert.GreaterOrEqual(t, len(chunk), MinSize, "Chunk is below the minimum size")
+				}
+			}
+		})
+	}
+}
+
+func TestSplit_ShiftResistant(t *testing.T) {
+	t.Parallel()
+
+	data := randomData(2, 1<<20)
+	original := split(t, data)
+	shifted := split(t, append([]byte("inserted"), data...))
+
+	known := map[string]struct{}{}
+	for _, chunk := range original {
+		known[string(chunk)] = struct{}{}
+	}
+
+	matching := 0
+	for _, chunk := range shifted {
+		if _, ok := known[string(chunk)]; ok {
+			matching++
+		}
+	}
+
+	assert.GreaterOrEqual(t, matching, len(original)-2, "Expected only the chunks around the insertion to change")
+}
@@ -0,0 +1,153 @@
+package chunk
+
+import (
+	"crypto/sha256"
+	"os"
+	"sort"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// RootFiles is the name under which files directly inside the root are reported.
+const RootFiles = "."
+
+// Usage describes the storage a part of the tree occupies with and without deduplication.
+//
+// Fields:
+//   - Name: The name of the top-level entry, or RootFiles for the files directly in the root.
+//   - Total: The number of bytes read.
+//   - Unique: The number of bytes left if identical chunks were stored once.
+//   - Chunks: The number of chunks.
+//   - UniqueChunks: The number of distinct chunks.
+type Usage struct {
+	Name         string
+	Total        int64
+	Unique       int64
+	Chunks       int
+	UniqueChunks int
+}
+
+// Savings returns the fraction (0 to 1) of bytes saved by deduplication.
+func (u Usage) Savings() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+
+	return 1 - float64(u.Unique)/float64(u.Total)
+}
+
+// Report is the result of Estimate.
+//
+// Fields:
+//   - Usage: The usage of the whole tree.
+//   - Entries: The usage of each top-level directory on its own, sorted by total size (largest first).
+type Report struct {
+	Usage
+	Entries []Usage
+}
+
+type counter struct {
+	usage *Usage
+	seen  map[[sha256.Size]byte]struct{}
+}
+
+func newCounter(name string) *counter {
+	return &counter{usage: &Usage{Name: name}, seen: map[[sha256.Size]byte]struct{}{}}
+}
+
+func (c *counter) add(hash [sha256.Size]byte, size int) {
+	c.usage.Total += int64(size)
+	c.usage.Chunks++
+
+	if _, ok := c.seen[hash]; !ok {
+		c.seen[hash] = struct{}{}
+		c.usage.Unique += int64(size)
+		c.usage.UniqueChunks++
+	}
+}
+
+// Estimate reads every file of the scanned tree below root, splits it into content-defined
+// chunks and hashes them with SHA-256 to estimate how much space block-level deduplication
+// would save. Each top-level entry is additionally deduplicated on its own, so the savings
+// within it are independent of the rest of the tree. Files directly in the root are grouped
+// as RootFiles. Anything that is not a regular file, like symlinks, is skipped.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - *Report: The estimated usage of the whole tree and of each top-level entry.
+//   - error: An error if a file cannot be read.
+func Estimate(root *models.Item) (*Report, error) {
+	total := newCounter(root.Name)
+	report := &Report{}
+
+	rootFiles := newCounter(RootFiles)
+	for _, child := range root.Children {
+		entry := rootFiles
+		if child.ItemType == models.ItemTypeDirectory {
+			entry = newCounter(child.Name)
+		}
+
+		if err := estimate(child, total, entry); err != nil {
+			return nil, err
+		}
+
+		if entry != rootFiles {
+			report.Entries = append(report.Entries, *entry.usage)
+		}
+	}
+
+	if rootFiles.usage.Chunks > 0 {
+		report.Entries = append(report.Entries, *rootFiles.usage)
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Total > report.Entries[j].Total
+	})
+	report.Usage = *total.usage
+
+	return report, nil
+}
+
+func estimate(item *models.Item, counters ...*counter) error {
+	if item.ItemType == models.ItemTypeDirectory {
+		for _, child := range item.Children {
+			if err := estimate(child, counters...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	info, err := os.Lstat(item.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(item.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	return Split(file, func(chunk []byte) error {
+		hash := sha256.Sum256(chunk)
+		for _, c := range counters {
+			c.add(hash, len(chunk))
+		}
+
+		return nil
+	})
+}
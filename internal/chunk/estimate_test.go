@@ -0,0 +1,52 @@
+package chunk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	shared := randomData(3, 256<<10)
+	files := map[string][]byte{
+		"backup/one":  shared,
+		"backup/two":  shared,
+		"unique/file": randomData(4, 64<<10),
+		"root.txt":    []byte("root"),
+	}
+	for path, content := range files {
+		full := filepath.Join(base, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, content, 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	_, err := utils.WalkAndCollect(root, base, 0)
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	report, err := Estimate(root)
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, int64(2*len(shared)+64<<10+4), report.Total)
+	assert.Equal(t, int64(len(shared)+64<<10+4), report.Unique)
+
+	assert.Len(t, report.Entries, 3)
+	assert.Equal(t, "backup", report.Entries[0].Name)
+	assert.Equal(t, int64(len(shared)), report.Entries[0].Unique)
+	assert.InDelta(t, 0.5, report.Entries[0].Savings(), 0.001)
+	assert.Equal(t, "unique", report.Entries[1].Name)
+	assert.InDelta(t, 0, report.Entries[1].Savings(), 0.001)
+	assert.Equal(t, RootFiles, report.Entries[2].Name)
+}
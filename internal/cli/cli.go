@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/StevenCyb/MemSpace/internal/unit"
 
@@ -12,12 +13,45 @@ import (
 // Arguments represents the configuration options for a CLI command.
 // It includes the following fields:
 //
-// - BasePath: The base directory path where the operation will start.
-// - DirectoryOnly: A flag indicating whether to process only directories.
-// - Recursive: A flag indicating whether to process directories recursively.
-// - Depth: An optional pointer to an integer specifying the maximum depth for recursion.
-// - Threshold: An optional pointer to a unit.Size value specifying a size threshold for filtering.
-// - Memory: A flag indicating whether to Show drive memory.
+//   - BasePath: The base directory path where the operation will start.
+//   - DirectoryOnly: A flag indicating whether to process only directories.
+//   - Recursive: A flag indicating whether to process directories recursively.
+//   - Depth: An optional pointer to an integer specifying the maximum depth for recursion.
+//   - Threshold: An optional pointer to a unit.Size value specifying a size threshold for filtering.
+//   - Memory: A flag indicating whether to Show drive memory.
+//   - Save: An optional file path the scan is written to as a snapshot.
+//   - HTML: An optional file path a self-contained HTML report of the scan is written to.
+//   - SVG: An optional file path an SVG image of the scan is written to.
+//   - SVGChart: The chart drawn in the SVG image, "treemap" or "sunburst".
+//   - SVGColor: Colors the SVG image by file "type" or nesting "depth".
+//   - Cache: An optional snapshot file whose unchanged directories are reused instead of rescanned.
+//   - Watch: A flag indicating whether to keep watching the tree for changes after the initial scan.
+//   - Interval: The interval in which changes are applied and the output is refreshed.
+//   - Growth: A flag indicating whether to rank items by their growth rate between periodic scans.
+//   - Limit: The maximum number of rows printed by reports like the growth rate table.
+//   - Top: Lists the largest "files", "dirs" or "all" (both) instead of the tree, empty to print the tree.
+//   - Browse: A flag indicating whether to browse the scanned tree in an interactive full-screen terminal UI.
+//   - Treemap: A flag indicating whether to draw a treemap sized to the terminal instead of the line tree.
+//   - Percent: A flag indicating whether to show the share of the parent and root directory next to the tree.
+//   - Bar: A flag indicating whether to show a bar proportional to the share of the root directory next to the tree.
+//   - Sort: Sorts the children of every directory of the tree by "size", "name", "count" or "mtime", empty for scan order.
+//   - Order: Forces the direction of the sort, "asc" or "desc", empty for the default of the sort key.
+//   - Natural: A flag indicating whether to compare numbers in names by value (implies sorting by name if Sort is empty).
+//   - MaxChildren: The maximum number of children printed per directory of the tree, 0 for no limit.
+//   - MinPercent: The minimum share of their directory in percent of children printed individually, 0 for no limit.
+//   - Format: The output format of the tree, "tree" for the colored tree, "json" for a JSON document,
+//     "ndjson" for one JSON object per line, written while scanning, "csv"/"tsv" for a flat table,
+//     "ncdu" for an ncdu JSON dump or "folded" for folded stack lines read by flame graph tools.
+//   - Import: An optional ncdu JSON dump the tree is read from instead of scanning the base path.
+//   - DU: Prints du-compatible lines with sizes as "blocks", "human" or "bytes" instead of the tree, empty to print the tree.
+//   - MaxDepth: An optional pointer to the maximum depth of the du output, counted like du --max-depth (0 prints only the total).
+//   - Command: The selected subcommand, empty if none was given.
+//   - Diff: The arguments of the diff command, nil if another command was selected.
+//   - Dupes: The arguments of the dupes command, nil if another command was selected.
+//   - Undo: The arguments of the undo command, nil if another command was selected.
+//   - Empty: The arguments of the empty command, nil if another command was selected.
+//   - Histogram: The arguments of the histogram command, nil if another command was selected.
+//   - Remove: The arguments of the remove command, nil if another command was selected.
 type Arguments struct {
 	BasePath      string
 	DirectoryOnly bool
@@ -25,6 +59,119 @@ type Arguments struct {
 	Depth         *int
 	Threshold     *unit.Size
 	Memory        bool
+	Save          string
+	HTML          string
+	SVG           string
+	SVGChart      string
+	SVGColor      string
+	Cache         string
+	Watch         bool
+	Interval      time.Duration
+	Growth        bool
+	Limit         int
+	Top           string
+	Browse        bool
+	Treemap       bool
+	Percent       bool
+	Bar           bool
+	Sort          string
+	Order         string
+	Natural       bool
+	MaxChildren   int
+	MinPercent    float64
+	Format        string
+	Import        string
+	DU            string
+	MaxDepth      *int
+	Command       Command
+	Diff          *DiffArguments
+	Dupes         *DupesArguments
+	Undo          *UndoArguments
+	Empty         *EmptyArguments
+	Histogram     *HistogramArguments
+	Remove        *RemoveArguments
+}
+
+// Command identifies a subcommand of the CLI.
+type Command string
+
+const (
+	// CommandDiff compares two snapshots or a snapshot against a live scan.
+	CommandDiff Command = "diff"
+	// CommandDupes searches the scanned tree for duplicate files.
+	CommandDupes Command = "dupes"
+	// CommandEstimate estimates the savings of block-level deduplication for the scanned tree.
+	CommandEstimate Command = "estimate"
+	// CommandEmpty lists and optionally removes zero-byte files and empty directories.
+	CommandEmpty Command = "empty"
+	// CommandHistogram prints the distribution of file sizes.
+	CommandHistogram Command = "histogram"
+	// CommandRemove deletes items of the scanned tree or moves them into the trash.
+	CommandRemove Command = "remove"
+	// CommandUndo reverts the replacements recorded in a dedup journal.
+	CommandUndo Command = "undo"
+)
+
+// DiffArguments represents the arguments of the diff command.
+//
+// - Old: The snapshot file of the older scan.
+// - New: The snapshot file of the newer scan. If empty, the base path is scanned instead.
+type DiffArguments struct {
+	Old string
+	New string
+}
+
+// DupesArguments represents the arguments of the dupes command.
+//
+// - Link: The way duplicates are replaced ("hardlink" or "reflink"), empty to only list them.
+// - Metadata: Which metadata replaced duplicates keep ("original" or "copy").
+// - DryRun: A flag indicating whether to only print what would be replaced.
+// - Journal: The file replacements are recorded in, so they can be undone.
+type DupesArguments struct {
+	Link     string
+	Metadata string
+	DryRun   bool
+	Journal  string
+}
+
+// EmptyArguments represents the arguments of the empty command.
+//
+// - Remove: A flag indicating whether to remove the empty files and directories.
+// - DryRun: A flag indicating whether to only print what would be removed.
+type EmptyArguments struct {
+	Remove bool
+	DryRun bool
+}
+
+// HistogramArguments represents the arguments of the histogram command.
+//
+// - PerDirectory: A flag indicating whether to print a histogram per top-level directory.
+// - Format: The output format, "chart" for an ASCII bar chart or "tsv" for tab-separated values.
+type HistogramArguments struct {
+	PerDirectory bool
+	Format       string
+}
+
+// RemoveArguments represents the arguments of the remove command.
+//
+// - Paths: The paths of the items to remove, as part of the scanned tree.
+// - Trash: A flag indicating whether to move the items into the trash instead of deleting them.
+// - DryRun: A flag indicating whether to only print what would be removed.
+// - Yes: A flag indicating whether to skip the confirmation prompt.
+type RemoveArguments struct {
+	Paths  []string
+	Trash  bool
+	DryRun bool
+	Yes    bool
+}
+
+// UndoArguments represents the arguments of the undo command.
+//
+// - Journal: The journal written by the dupes command.
+// - DryRun: A flag indicating whether to only print what would be restored.
+type UndoArguments struct {
+	Journal string
+	DryRun  bool
 }
 
 // New creates a new instance of Arguments by parsing the provided command-line arguments.
@@ -45,6 +192,44 @@ type Arguments struct {
 //   - -e, --depth: Specifies the depth of recursion (default: -1 for unlimited depth).
 //   - -t, --threshold: Specifies a threshold value to alert on.
 //   - -m, --memory: If set, shows driver memory.
+//   - -s, --save: Saves a snapshot of the scan to the given file.
+//   - -H, --html: Writes a self-contained HTML report with a treemap and tree table to the given file.
+//   - -S, --svg: Writes an SVG image of the scan to the given file.
+//   - --svg-chart: The chart drawn in the SVG image, treemap or sunburst (default: treemap).
+//   - --svg-color: Colors the SVG image by type or depth (default: type).
+//   - -c, --cache: Reuses unchanged directories from the given snapshot instead of rescanning them.
+//   - -w, --watch: If set, keeps watching the tree for changes and redraws the output.
+//   - -i, --interval: The refresh interval of the watch mode and growth rate table (default: 1s).
+//   - -g, --growth: If set, scans every interval and ranks items by their growth rate.
+//   - -l, --limit: The maximum number of rows printed by reports (default: 20).
+//   - -T, --top: Lists the largest files, directories or both (default if given without value: all).
+//   - -b, --browse: If set, browses the scanned tree interactively in a full-screen terminal UI.
+//   - -M, --treemap: If set, draws a treemap sized to the terminal instead of the line tree.
+//   - -P, --percent: If set, shows the share of the parent and root directory in a right-hand column of the tree.
+//   - -B, --bar: If set, shows a bar proportional to the share of the root directory in a right-hand column of the tree.
+//   - -o, --sort: Sorts the children of every directory of the tree by size, name, count or mtime (default: scan order).
+//   - --order: Forces ascending or descending order, asc or desc (default: asc for name, desc otherwise).
+//   - --natural: If set, compares numbers in names by value, so file2 is sorted before file10.
+//   - --max-children: Prints only the largest children per directory and collapses the others into one line (default: 0 for no limit).
+//   - --min-percent: Collapses children smaller than the given share of their directory in percent into one line (default: 0 for no limit).
+//   - -f, --format: The output format of the tree, tree, json, ndjson, csv, tsv, ncdu or folded (default: tree).
+//   - -I, --import: Reads the tree from the given ncdu JSON dump instead of scanning the path.
+//   - -u, --du: Prints du-compatible lines with sizes as blocks, human or bytes (default if given without value: blocks).
+//   - --max-depth: Limits the du output like du --max-depth (default: -1 for unlimited depth).
+//
+// The supported commands are:
+//   - diff OLD [NEW]: Compares the snapshot OLD with the snapshot NEW or, if omitted, with a live scan.
+//   - dupes: Lists sets of duplicate files, skipping files smaller than the threshold.
+//     With --link=hardlink|reflink duplicates are replaced, recorded in the --journal file
+//     and keeping the metadata selected by --metadata=original|copy. --dry-run only prints the plan.
+//   - estimate: Estimates the savings of block-level deduplication per top-level directory.
+//   - empty: Lists zero-byte files and empty directories, --remove deletes them bottom-up
+//     and --dry-run only prints what would be removed.
+//   - histogram: Prints the distribution of file sizes in logarithmic buckets, --per-dir for
+//     each top-level directory and --format=chart|tsv as bar chart or tab-separated values.
+//   - remove PATH...: Deletes the given items of the scanned tree after a confirmation, --trash moves
+//     them into the trash instead, --dry-run only prints what would be removed and --yes skips the prompt.
+//   - undo JOURNAL: Restores the duplicates replaced by the dupes command as independent copies.
 //
 // Example usage:
 //
@@ -55,15 +240,82 @@ type Arguments struct {
 //	}
 func New(args []string) (*Arguments, error) {
 	var opts struct {
-		Path      string `short:"p" long:"path" default:"." description:"The base path to start scanning from"`
-		Dir       bool   `short:"d" long:"dir" description:"Only show directories"`
-		Recursive bool   `short:"r" long:"recursive" description:"Show files (and directories) Recursively"`
-		Depth     int    `short:"e" long:"depth" default:"-1" description:"The depth of recursion"`
-		Threshold string `short:"t" long:"threshold" default:"" description:"Show only files or directories larger than the threshold"`
-		Memory    bool   `short:"m" long:"memory" description:"Show drive memory"`
+		Path      string        `short:"p" long:"path" default:"." description:"The base path to start scanning from"`
+		Dir       bool          `short:"d" long:"dir" description:"Only show directories"`
+		Recursive bool          `short:"r" long:"recursive" description:"Show files (and directories) Recursively"`
+		Depth     int           `short:"e" long:"depth" default:"-1" description:"The depth of recursion"`
+		Threshold string        `short:"t" long:"threshold" default:"" description:"Show only files or directories larger than the threshold"`
+		Memory    bool          `short:"m" long:"memory" description:"Show drive memory"`
+		Save      string        `short:"s" long:"save" description:"Save a snapshot of the scan to the given file"`
+		HTML      string        `short:"H" long:"html" description:"Write a self-contained HTML report with a treemap of the scan to the given file"`
+		SVG       string        `short:"S" long:"svg" description:"Write an SVG image of the scan to the given file"`
+		SVGChart  string        `long:"svg-chart" choice:"treemap" choice:"sunburst" default:"treemap" description:"The chart drawn in the SVG image"`
+		SVGColor  string        `long:"svg-color" choice:"type" choice:"depth" default:"type" description:"Color the SVG image by type or depth"`
+		Cache     string        `short:"c" long:"cache" description:"Reuse directories unchanged since the given snapshot instead of rescanning them"`
+		Watch     bool          `short:"w" long:"watch" description:"Keep watching the tree for changes and redraw the output"`
+		Interval  time.Duration `short:"i" long:"interval" default:"1s" description:"The refresh interval of the watch mode and growth rate table"`
+		Growth    bool          `short:"g" long:"growth" description:"Scan every interval and rank files and directories by growth rate"`
+		Limit     int           `short:"l" long:"limit" default:"20" description:"The maximum number of rows printed by reports"`
+		Top       string        `short:"T" long:"top" optional:"yes" optional-value:"all" choice:"files" choice:"dirs" choice:"all" description:"List the largest files, directories or both instead of the tree"`
+		Browse    bool          `short:"b" long:"browse" description:"Browse the scanned tree interactively in a full-screen terminal UI"`
+		Treemap   bool          `short:"M" long:"treemap" description:"Draw a treemap sized to the terminal instead of the line tree"`
+		Percent   bool          `short:"P" long:"percent" description:"Show the share of the parent and root directory next to the tree"`
+		Bar       bool          `short:"B" long:"bar" description:"Show a bar proportional to the share of the root directory next to the tree"`
+		Sort      string        `short:"o" long:"sort" choice:"size" choice:"name" choice:"count" choice:"mtime" description:"Sort the children of every directory of the tree"`
+		Order     string        `long:"order" choice:"asc" choice:"desc" description:"Force the direction of the sort (default: asc for name, desc otherwise)"`
+		Natural   bool          `long:"natural" description:"Compare numbers in names by value, so file2 is sorted before file10"`
+		Children  int           `long:"max-children" default:"0" description:"Print only the largest children per directory and collapse the others into one line"`
+		Cutoff    float64       `long:"min-percent" default:"0" description:"Collapse children smaller than the given share of their directory in percent into one line"`
+		Format    string        `short:"f" long:"format" choice:"tree" choice:"json" choice:"ndjson" choice:"csv" choice:"tsv" choice:"ncdu" choice:"folded" default:"tree" description:"The output format of the tree"`
+		Import    string        `short:"I" long:"import" description:"Read the tree from the given ncdu JSON dump instead of scanning the path"`
+		DU        string        `short:"u" long:"du" optional:"yes" optional-value:"blocks" choice:"blocks" choice:"human" choice:"bytes" description:"Print size and path lines like du instead of the tree"`
+		MaxDepth  int           `long:"max-depth" default:"-1" description:"Only print entries up to this depth in the du output, like du --max-depth"`
+
+		Diff struct {
+			Args struct {
+				Old string `positional-arg-name:"OLD" required:"yes" description:"Snapshot of the older scan"`
+				New string `positional-arg-name:"NEW" description:"Snapshot of the newer scan (default: live scan of the path)"`
+			} `positional-args:"yes"`
+		} `command:"diff" description:"Show what grew or shrank between two snapshots or a snapshot and a live scan"`
+
+		Dupes struct {
+			Link     string `long:"link" choice:"hardlink" choice:"reflink" description:"Replace duplicates with links to the first file of their set"`
+			Metadata string `long:"metadata" choice:"original" choice:"copy" default:"original" description:"Metadata kept by replaced duplicates (copy requires reflinks)"`
+			DryRun   bool   `long:"dry-run" description:"Only print what would be replaced"`
+			Journal  string `long:"journal" description:"Record replacements in the given file to undo them later"`
+		} `command:"dupes" description:"List duplicate files sorted by reclaimable space"`
+
+		Estimate struct{} `command:"estimate" description:"Estimate the savings of block-level deduplication per top-level directory"`
+
+		Empty struct {
+			Remove bool `long:"remove" description:"Remove the empty files and directories bottom-up"`
+			DryRun bool `long:"dry-run" description:"Only print what would be removed"`
+		} `command:"empty" description:"List zero-byte files and directories without content"`
+
+		Histogram struct {
+			PerDir bool   `long:"per-dir" description:"Print a histogram per top-level directory"`
+			Format string `long:"format" choice:"chart" choice:"tsv" default:"chart" description:"Print an ASCII bar chart or tab-separated values"`
+		} `command:"histogram" description:"Show the distribution of file sizes in logarithmic buckets"`
+
+		Remove struct {
+			Trash  bool `long:"trash" description:"Move the items into the trash instead of deleting them"`
+			DryRun bool `long:"dry-run" description:"Only print what would be removed"`
+			Yes    bool `short:"y" long:"yes" description:"Remove without asking for confirmation"`
+			Args   struct {
+				Paths []string `positional-arg-name:"PATH" required:"1" description:"Files or directories below the path to remove"`
+			} `positional-args:"yes"`
+		} `command:"remove" description:"Delete files and directories or move them into the trash and print the reclaimed size"`
+
+		Undo struct {
+			DryRun bool `long:"dry-run" description:"Only print what would be restored"`
+			Args   struct {
+				Journal string `positional-arg-name:"JOURNAL" required:"yes" description:"Journal written by the dupes command"`
+			} `positional-args:"yes"`
+		} `command:"undo" description:"Restore duplicates replaced by the dupes command as independent copies"`
 	}
 
 	parser := flags.NewParser(&opts, flags.Default)
+	parser.SubcommandsOptional = true
 	if _, err := parser.ParseArgs(args); flags.WroteHelp(err) {
 		return nil, err
 	} else if err != nil {
@@ -75,12 +327,84 @@ func New(args []string) (*Arguments, error) {
 		DirectoryOnly: opts.Dir,
 		Recursive:     opts.Recursive,
 		Memory:        opts.Memory,
+		Save:          opts.Save,
+		HTML:          opts.HTML,
+		SVG:           opts.SVG,
+		SVGChart:      opts.SVGChart,
+		SVGColor:      opts.SVGColor,
+		Cache:         opts.Cache,
+		Watch:         opts.Watch,
+		Interval:      opts.Interval,
+		Growth:        opts.Growth,
+		Limit:         opts.Limit,
+		Top:           opts.Top,
+		Browse:        opts.Browse,
+		Treemap:       opts.Treemap,
+		Percent:       opts.Percent,
+		Bar:           opts.Bar,
+		Sort:          opts.Sort,
+		Order:         opts.Order,
+		Natural:       opts.Natural,
+		MaxChildren:   opts.Children,
+		MinPercent:    opts.Cutoff,
+		Format:        opts.Format,
+		Import:        opts.Import,
+		DU:            opts.DU,
+	}
+
+	if parser.Active != nil {
+		arguments.Command = Command(parser.Active.Name)
+	}
+
+	switch arguments.Command {
+	case CommandDiff:
+		arguments.Diff = &DiffArguments{
+			Old: opts.Diff.Args.Old,
+			New: opts.Diff.Args.New,
+		}
+	case CommandDupes:
+		arguments.Dupes = &DupesArguments{
+			Link:     opts.Dupes.Link,
+			Metadata: opts.Dupes.Metadata,
+			DryRun:   opts.Dupes.DryRun,
+			Journal:  opts.Dupes.Journal,
+		}
+	case CommandEmpty:
+		arguments.Empty = &EmptyArguments{
+			Remove: opts.Empty.Remove,
+			DryRun: opts.Empty.DryRun,
+		}
+	case CommandHistogram:
+		arguments.Histogram = &HistogramArguments{
+			PerDirectory: opts.Histogram.PerDir,
+			Format:       opts.Histogram.Format,
+		}
+	case CommandRemove:
+		arguments.Remove = &RemoveArguments{
+			Paths:  opts.Remove.Args.Paths,
+			Trash:  opts.Remove.Trash,
+			DryRun: opts.Remove.DryRun,
+			Yes:    opts.Remove.Yes,
+		}
+	case CommandUndo:
+		arguments.Undo = &UndoArguments{
+			Journal: opts.Undo.Args.Journal,
+			DryRun:  opts.Undo.DryRun,
+		}
 	}
 
 	if opts.Depth >= 0 {
 		arguments.Depth = &opts.Depth
 	}
 
+	if arguments.Natural && arguments.Sort == "" {
+		arguments.Sort = "name"
+	}
+
+	if opts.MaxDepth >= 0 {
+		arguments.MaxDepth = &opts.MaxDepth
+	}
+
 	var err error
 	arguments.Threshold, err = unit.NewFromString(&opts.Threshold)
 	if err != nil {
@@ -96,7 +420,8 @@ func New(args []string) (*Arguments, error) {
 
 // Verify checks the validity of the Arguments struct by ensuring that the BasePath field
 // is not empty and that the specified path exists in the filesystem.
-// It returns an error if the BasePath is empty or if the path does not exist.
+// It also ensures that the given snapshot files (cache and diff command) exist.
+// It returns an error if the BasePath is empty or if a path does not exist.
 func (a Arguments) Verify() error {
 	if a.BasePath == "" {
 		return fmt.Errorf("base path cannot be empty")
@@ -106,5 +431,57 @@ func (a Arguments) Verify() error {
 		return fmt.Errorf("base path does not exist: %s", a.BasePath)
 	}
 
+	if a.Interval <= 0 {
+		return fmt.Errorf("interval must be positive: %s", a.Interval)
+	}
+
+	if a.Order != "" && a.Sort == "" {
+		return fmt.Errorf("the order requires a sort key")
+	}
+
+	if a.MaxChildren < 0 {
+		return fmt.Errorf("max children must not be negative: %d", a.MaxChildren)
+	}
+
+	if a.MinPercent < 0 || a.MinPercent > 100 {
+		return fmt.Errorf("min percent must be between 0 and 100: %g", a.MinPercent)
+	}
+
+	if a.Limit <= 0 {
+		return fmt.Errorf("limit must be positive: %d", a.Limit)
+	}
+
+	if _, err := os.Stat(a.Cache); a.Cache != "" && os.IsNotExist(err) {
+		return fmt.Errorf("snapshot does not exist: %s", a.Cache)
+	}
+
+	if _, err := os.Stat(a.Import); a.Import != "" && os.IsNotExist(err) {
+		return fmt.Errorf("ncdu dump does not exist: %s", a.Import)
+	}
+
+	if a.Dupes != nil {
+		if a.Dupes.Link == "hardlink" && a.Dupes.Metadata == "copy" {
+			return fmt.Errorf("hardlinks always share the metadata of the original")
+		}
+
+		if a.Dupes.Link != "" && !a.Dupes.DryRun && a.Dupes.Journal == "" {
+			return fmt.Errorf("a journal is required to replace duplicates")
+		}
+	}
+
+	if a.Undo != nil {
+		if _, err := os.Stat(a.Undo.Journal); os.IsNotExist(err) {
+			return fmt.Errorf("journal does not exist: %s", a.Undo.Journal)
+		}
+	}
+
+	if a.Diff != nil {
+		for _, snapshot := range []string{a.Diff.Old, a.Diff.New} {
+			if _, err := os.Stat(snapshot); snapshot != "" && os.IsNotExist(err) {
+				return fmt.Errorf("snapshot does not exist: %s", snapshot)
+			}
+		}
+	}
+
 	return nil
 }
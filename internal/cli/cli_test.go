@@ -2,6 +2,7 @@ package cli
 
 import (
 	"testing"
+	"time"
 
 	"github.com/StevenCyb/MemSpace/internal/unit"
 
@@ -49,6 +50,11 @@ func TestNew(t *testing.T) {
 			args: []string{},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         nil,
@@ -61,6 +67,11 @@ func TestNew(t *testing.T) {
 			args: []string{"--path", "/tmp"},
 			want: &Arguments{
 				BasePath:      "/tmp",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         nil,
@@ -73,6 +84,11 @@ func TestNew(t *testing.T) {
 			args: []string{"-p", "/tmp"},
 			want: &Arguments{
 				BasePath:      "/tmp",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         nil,
@@ -85,6 +101,11 @@ func TestNew(t *testing.T) {
 			args: []string{"--dir"},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: true,
 				Recursive:     false,
 				Depth:         nil,
@@ -97,6 +118,11 @@ func TestNew(t *testing.T) {
 			args: []string{"-r"},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     true,
 				Depth:         nil,
@@ -109,6 +135,11 @@ func TestNew(t *testing.T) {
 			args: []string{"--depth", "3"},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         intPtr(3),
@@ -121,6 +152,11 @@ func TestNew(t *testing.T) {
 			args: []string{"--threshold", "10MB"},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         nil,
@@ -133,6 +169,11 @@ func TestNew(t *testing.T) {
 			args: []string{"--memory"},
 			want: &Arguments{
 				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "tree",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
 				DirectoryOnly: false,
 				Recursive:     false,
 				Depth:         nil,
@@ -141,6 +182,523 @@ func TestNew(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "Save snapshot",
+			args: []string{"--save", "scan.json"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Save:     "scan.json",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Watch mode",
+			args: []string{"--watch", "--interval", "500ms"},
+			want: &Arguments{
+				BasePath: ".",
+				Watch:    true,
+				Interval: 500 * time.Millisecond,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Invalid interval",
+			args:      []string{"--interval", "0s"},
+			expectErr: true,
+		},
+		{
+			name: "Growth rate table",
+			args: []string{"--growth", "--limit", "5"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Growth:   true,
+				Limit:    5,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Top report",
+			args: []string{"--top", "-l", "5"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    5,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Top:      "all",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Top files report",
+			args: []string{"--top=files"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Top:      "files",
+			},
+			expectErr: false,
+		},
+		{
+			name: "JSON format",
+			args: []string{"-f", "json", "-d", "-e", "1"},
+			want: &Arguments{
+				BasePath:      ".",
+				Interval:      time.Second,
+				Limit:         20,
+				Format:        "json",
+				SVGChart:      "treemap",
+				SVGColor:      "type",
+				DirectoryOnly: true,
+				Depth:         intPtr(1),
+			},
+			expectErr: false,
+		},
+		{
+			name: "NDJSON format",
+			args: []string{"--format=ndjson"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "ndjson",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "CSV format",
+			args: []string{"-f", "csv", "-t", "1KB"},
+			want: &Arguments{
+				BasePath:  ".",
+				Interval:  time.Second,
+				Limit:     20,
+				Format:    "csv",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+				Threshold: &unit.Size{Size: 1024},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Import ncdu dump and export as ncdu",
+			args: []string{"-I", "cli.go", "-f", "ncdu"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "ncdu",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Import:   "cli.go",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Missing ncdu dump",
+			args:      []string{"--import", "missing.json"},
+			expectErr: true,
+		},
+		{
+			name: "du output",
+			args: []string{"--du", "--max-depth", "0"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				DU:       "blocks",
+				MaxDepth: intPtr(0),
+			},
+			expectErr: false,
+		},
+		{
+			name: "du output with human sizes",
+			args: []string{"-u=human"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				DU:       "human",
+			},
+			expectErr: false,
+		},
+		{
+			name: "HTML report",
+			args: []string{"--html", "report.html", "-t", "1MB"},
+			want: &Arguments{
+				BasePath:  ".",
+				Interval:  time.Second,
+				Limit:     20,
+				Format:    "tree",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+				HTML:      "report.html",
+				Threshold: &unit.Size{Size: 1024 * 1024},
+			},
+			expectErr: false,
+		},
+		{
+			name: "SVG sunburst",
+			args: []string{"-S", "usage.svg", "--svg-chart", "sunburst", "--svg-color=depth"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVG:      "usage.svg",
+				SVGChart: "sunburst",
+				SVGColor: "depth",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Folded stacks format",
+			args: []string{"--format", "folded"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "folded",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Terminal treemap",
+			args: []string{"-M", "-e", "2"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Treemap:  true,
+				Depth:    intPtr(2),
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Percentages and bars",
+			args: []string{"-r", "-P", "--bar"},
+			want: &Arguments{
+				BasePath:  ".",
+				Recursive: true,
+				Interval:  time.Second,
+				Limit:     20,
+				Percent:   true,
+				Bar:       true,
+				Format:    "tree",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Sort by size ascending",
+			args: []string{"--sort", "size", "--order", "asc"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Sort:     "size",
+				Order:    "asc",
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Natural order implies sorting by name",
+			args: []string{"--natural"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Sort:     "name",
+				Natural:  true,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Order without sort key",
+			args:      []string{"--order", "desc"},
+			expectErr: true,
+		},
+		{
+			name:      "Invalid sort key",
+			args:      []string{"-o", "color"},
+			expectErr: true,
+		},
+		{
+			name: "Collapse small children",
+			args: []string{"--max-children", "10", "--min-percent", "0.5"},
+			want: &Arguments{
+				BasePath:    ".",
+				Interval:    time.Second,
+				Limit:       20,
+				MaxChildren: 10,
+				MinPercent:  0.5,
+				Format:      "tree",
+				SVGChart:    "treemap",
+				SVGColor:    "type",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Negative max children",
+			args:      []string{"--max-children", "-1"},
+			expectErr: true,
+		},
+		{
+			name:      "Min percent above 100",
+			args:      []string{"--min-percent", "101"},
+			expectErr: true,
+		},
+		{
+			name: "Interactive browser",
+			args: []string{"--browse"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Browse:   true,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Invalid format",
+			args:      []string{"--format", "xml"},
+			expectErr: true,
+		},
+		{
+			name:      "Invalid limit",
+			args:      []string{"--limit", "0"},
+			expectErr: true,
+		},
+		{
+			name: "Cache snapshot",
+			args: []string{"--cache", "cli.go"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Cache:    "cli.go",
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Missing cache snapshot",
+			args:      []string{"--cache", "missing.json"},
+			expectErr: true,
+		},
+		{
+			name: "Diff command with live scan",
+			args: []string{"diff", "cli.go"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandDiff,
+				Diff:     &DiffArguments{Old: "cli.go"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Diff command with two snapshots",
+			args: []string{"-r", "diff", "cli.go", "cli_test.go"},
+			want: &Arguments{
+				BasePath:  ".",
+				Interval:  time.Second,
+				Limit:     20,
+				Format:    "tree",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+				Recursive: true,
+				Command:   CommandDiff,
+				Diff:      &DiffArguments{Old: "cli.go", New: "cli_test.go"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Dupes command",
+			args: []string{"dupes", "-t", "1MB"},
+			want: &Arguments{
+				BasePath:  ".",
+				Interval:  time.Second,
+				Limit:     20,
+				Format:    "tree",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+				Threshold: &unit.Size{Size: 1024 * 1024},
+				Command:   CommandDupes,
+				Dupes:     &DupesArguments{Metadata: "original"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Dupes command with reflinks",
+			args: []string{"dupes", "--link", "reflink", "--metadata", "copy", "--journal", "dedup.journal"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandDupes,
+				Dupes:    &DupesArguments{Link: "reflink", Metadata: "copy", Journal: "dedup.journal"},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Dupes command with hardlinks keeping metadata of the copy",
+			args:      []string{"dupes", "--link", "hardlink", "--metadata", "copy", "--dry-run"},
+			expectErr: true,
+		},
+		{
+			name:      "Dupes command without journal",
+			args:      []string{"dupes", "--link", "hardlink"},
+			expectErr: true,
+		},
+		{
+			name:      "Dupes command with invalid link",
+			args:      []string{"dupes", "--link", "symlink", "--dry-run"},
+			expectErr: true,
+		},
+		{
+			name: "Estimate command",
+			args: []string{"estimate"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandEstimate,
+			},
+			expectErr: false,
+		},
+		{
+			name: "Empty command",
+			args: []string{"empty", "--remove", "--dry-run"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandEmpty,
+				Empty:    &EmptyArguments{Remove: true, DryRun: true},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Histogram command",
+			args: []string{"histogram", "--per-dir", "--format", "tsv"},
+			want: &Arguments{
+				BasePath:  ".",
+				Interval:  time.Second,
+				Limit:     20,
+				Format:    "tree",
+				SVGChart:  "treemap",
+				SVGColor:  "type",
+				Command:   CommandHistogram,
+				Histogram: &HistogramArguments{PerDirectory: true, Format: "tsv"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Remove command",
+			args: []string{"remove", "--trash", "--dry-run", "-y", "a", "b"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandRemove,
+				Remove:   &RemoveArguments{Paths: []string{"a", "b"}, Trash: true, DryRun: true, Yes: true},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Remove command without paths",
+			args:      []string{"remove", "--trash"},
+			expectErr: true,
+		},
+		{
+			name: "Undo command",
+			args: []string{"undo", "--dry-run", "cli.go"},
+			want: &Arguments{
+				BasePath: ".",
+				Interval: time.Second,
+				Limit:    20,
+				Format:   "tree",
+				SVGChart: "treemap",
+				SVGColor: "type",
+				Command:  CommandUndo,
+				Undo:     &UndoArguments{Journal: "cli.go", DryRun: true},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Undo command with missing journal",
+			args:      []string{"undo", "missing.journal"},
+			expectErr: true,
+		},
+		{
+			name:      "Diff command without snapshot",
+			args:      []string{"diff"},
+			expectErr: true,
+		},
+		{
+			name:      "Diff command with missing snapshot",
+			args:      []string{"diff", "missing.json"},
+			expectErr: true,
+		},
 	}
 
 	for _, tt_ := range tests {
@@ -0,0 +1,35 @@
+//go:build linux
+
+package dedup
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// clone creates dst as a copy-on-write clone of src using the FICLONE ioctl.
+// ErrReflinkUnsupported is returned if the file system cannot clone the file.
+func clone(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err != nil {
+		out.Close()
+		if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EXDEV) || errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOTTY) {
+			return ErrReflinkUnsupported
+		}
+		return err
+	}
+
+	return out.Close()
+}
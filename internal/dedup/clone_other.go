@@ -0,0 +1,8 @@
+//go:build !linux
+
+package dedup
+
+// clone is not supported on this platform and always returns ErrReflinkUnsupported.
+func clone(_, _ string) error {
+	return ErrReflinkUnsupported
+}
@@ -0,0 +1,385 @@
+package dedup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/dupes"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+var (
+	ErrReflinkUnsupported = errors.New("reflinks are not supported by the file system")
+	ErrInvalidPolicy      = errors.New("hardlinks always share the metadata of the original")
+)
+
+// Method is the way a duplicate is replaced.
+type Method string
+
+const (
+	// MethodHardlink replaces a duplicate by a hardlink to the original, which requires both to be on the same file system.
+	MethodHardlink Method = "hardlink"
+	// MethodReflink replaces a duplicate by a copy-on-write clone (FICLONE) of the original, if the file system supports it.
+	MethodReflink Method = "reflink"
+)
+
+// Metadata is the policy deciding which metadata (mode, owner and times) a replaced duplicate keeps.
+type Metadata string
+
+const (
+	// MetadataOriginal makes the replaced duplicate take over the metadata of the original.
+	MetadataOriginal Metadata = "original"
+	// MetadataCopy makes the replaced duplicate keep its own metadata, which is only possible for reflinks.
+	MetadataCopy Metadata = "copy"
+)
+
+// Options configures how duplicates are replaced.
+//
+// Fields:
+//   - Method: The way duplicates are replaced.
+//   - Metadata: The metadata policy of replaced duplicates.
+//   - DryRun: If set, actions are only planned but not executed.
+//   - Journal: Receives one JSON line per executed action, so it can be undone with Undo. May be nil.
+type Options struct {
+	Method   Method
+	Metadata Metadata
+	DryRun   bool
+	Journal  io.Writer
+}
+
+// Action describes the replacement of a duplicate by a link to the original.
+// Executed actions are written to the journal, including the metadata of the
+// duplicate before it was replaced.
+//
+// Fields:
+//   - Method: The way the duplicate was replaced.
+//   - Original: The path of the kept file.
+//   - Duplicate: The path of the replaced file.
+//   - Size: The size of the file in bytes.
+//   - Mode: The file mode of the duplicate before it was replaced.
+//   - ModTime: The modification time of the duplicate before it was replaced.
+//   - UID, GID: The owner of the duplicate before it was replaced, -1 if unknown.
+//   - Skipped: The reason why the action was not executed, empty if it was (or would be in a dry run).
+type Action struct {
+	Method    Method      `json:"method"`
+	Original  string      `json:"original"`
+	Duplicate string      `json:"duplicate"`
+	Size      int64       `json:"size"`
+	Mode      os.FileMode `json:"mode"`
+	ModTime   time.Time   `json:"mtime"`
+	UID       int         `json:"uid"`
+	GID       int         `json:"gid"`
+	Skipped   string      `json:"-"`
+}
+
+// Apply replaces every duplicate of the given sets by a link to the first file of its set.
+// Before a duplicate is replaced, its content is compared byte by byte with the original,
+// so a file modified since it was hashed is never lost. The replacement is written to a
+// temporary file next to the duplicate and renamed over it, so the duplicate is replaced atomically.
+//
+// Parameters:
+//   - sets: The duplicate sets as returned by dupes.Find.
+//   - opts: The options configuring the replacement.
+//
+// Returns:
+//   - []Action: All planned actions, including skipped ones with their reason.
+//   - error: An error if a replacement or writing the journal fails.
+func Apply(sets []dupes.Set, opts Options) ([]Action, error) {
+	if opts.Method == MethodHardlink && opts.Metadata == MetadataCopy {
+		return nil, ErrInvalidPolicy
+	}
+
+	actions := []Action{}
+	for _, set := range sets {
+		original := set.Paths[0]
+		originalInfo, err := os.Stat(original)
+		if err != nil {
+			return actions, err
+		}
+
+		for _, duplicate := range set.Paths[1:] {
+			action, info, err := plan(opts.Method, original, duplicate)
+			if err != nil {
+				return actions, err
+			}
+
+			if action.Skipped == "" && opts.Method == MethodHardlink && !sameDevice(originalInfo, info) {
+				action.Skipped = "on another file system"
+			}
+
+			if action.Skipped == "" && !opts.DryRun {
+				metadata := originalInfo
+				if opts.Metadata == MetadataCopy {
+					metadata = info
+				}
+
+				if err := replace(opts.Method, original, duplicate, metadata); err != nil {
+					if !errors.Is(err, ErrReflinkUnsupported) {
+						return actions, fmt.Errorf("failed to replace %s: %w", duplicate, err)
+					}
+					action.Skipped = err.Error()
+				} else if err := record(opts.Journal, action); err != nil {
+					return actions, err
+				}
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	return actions, nil
+}
+
+// Undo reverts the actions recorded in a journal written by Apply, in reverse order.
+// Every replaced duplicate that still has the content of its original is turned back into
+// an independent copy with the mode, owner and modification time it had before.
+//
+// Parameters:
+//   - journal: The path of the journal file.
+//   - dryRun: If set, actions are only planned but not executed.
+//
+// Returns:
+//   - []Action: All reverted actions, including skipped ones with their reason.
+//   - error: An error if the journal cannot be read or a copy cannot be restored.
+func Undo(journal string, dryRun bool) ([]Action, error) {
+	file, err := os.Open(journal)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	recorded := []Action{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var action Action
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			return nil, fmt.Errorf("failed to decode journal: %w", err)
+		}
+		recorded = append(recorded, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	actions := make([]Action, 0, len(recorded))
+	for i := len(recorded) - 1; i >= 0; i-- {
+		action := recorded[i]
+
+		if same, err := equal(action.Original, action.Duplicate); err != nil {
+			if !os.IsNotExist(err) {
+				return actions, err
+			}
+			action.Skipped = "no longer exists"
+		} else if !same {
+			action.Skipped = "changed since it was replaced"
+		}
+
+		if action.Skipped == "" && !dryRun {
+			if err := restore(action); err != nil {
+				return actions, fmt.Errorf("failed to restore %s: %w", action.Duplicate, err)
+			}
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// Reclaimed returns the number of bytes freed (or to be freed) by the given actions.
+func Reclaimed(actions []Action) int64 {
+	var total int64
+	for _, action := range actions {
+		if action.Skipped == "" {
+			total += action.Size
+		}
+	}
+
+	return total
+}
+
+func plan(method Method, original, duplicate string) (Action, os.FileInfo, error) {
+	action := Action{Method: method, Original: original, Duplicate: duplicate}
+
+	info, err := os.Lstat(duplicate)
+	if err != nil {
+		if os.IsNotExist(err) {
+			action.Skipped = "no longer exists"
+			return action, nil, nil
+		}
+		return action, nil, err
+	}
+
+	action.Size, action.Mode, action.ModTime = info.Size(), info.Mode(), info.ModTime()
+	var ok bool
+	if action.UID, action.GID, ok = utils.GetOwner(info); !ok {
+		action.UID, action.GID = -1, -1
+	}
+
+	same, err := equal(original, duplicate)
+	if err != nil {
+		return action, info, err
+	}
+	if !same {
+		action.Skipped = "content differs from the original"
+	}
+
+	return action, info, nil
+}
+
+func replace(method Method, original, duplicate string, metadata os.FileInfo) error {
+	tmp := filepath.Join(filepath.Dir(duplicate), fmt.Sprintf(".%s.memspace-%d", filepath.Base(duplicate), time.Now().UnixNano()))
+
+	if method == MethodHardlink {
+		if err := os.Link(original, tmp); err != nil {
+			return err
+		}
+	} else {
+		if err := clone(original, tmp); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+
+		uid, gid, ok := utils.GetOwner(metadata)
+		if !ok {
+			uid, gid = -1, -1
+		}
+
+		if err := applyMetadata(tmp, metadata.Mode(), metadata.ModTime(), uid, gid); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+
+	if err := os.Rename(tmp, duplicate); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+func restore(action Action) error {
+	tmp := filepath.Join(filepath.Dir(action.Duplicate), fmt.Sprintf(".%s.memspace-%d", filepath.Base(action.Duplicate), time.Now().UnixNano()))
+
+	if err := copyFile(action.Original, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := applyMetadata(tmp, action.Mode, action.ModTime, action.UID, action.GID); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, action.Duplicate); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// applyMetadata sets owner, mode and modification time of path. An id of -1 keeps the owner.
+// Changing the owner requires privileges, so a permission error is ignored.
+func applyMetadata(path string, mode os.FileMode, modTime time.Time, uid, gid int) error {
+	if err := os.Chown(path, uid, gid); err != nil && !errors.Is(err, os.ErrPermission) {
+		return err
+	}
+
+	if err := os.Chmod(path, mode.Perm()); err != nil {
+		return err
+	}
+
+	return os.Chtimes(path, modTime, modTime)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+func record(journal io.Writer, action Action) error {
+	if journal == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+
+	if _, err := journal.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	if file, ok := journal.(*os.File); ok {
+		return file.Sync()
+	}
+
+	return nil
+}
+
+func sameDevice(a, b os.FileInfo) bool {
+	idA, okA := utils.GetFileID(a)
+	idB, okB := utils.GetFileID(b)
+
+	return !okA || !okB || idA.Device == idB.Device
+}
+
+// equal compares the content of two files byte by byte.
+func equal(a, b string) (bool, error) {
+	fileA, err := os.Open(a)
+	if err != nil {
+		return false, err
+	}
+	defer fileA.Close()
+
+	fileB, err := os.Open(b)
+	if err != nil {
+		return false, err
+	}
+	defer fileB.Close()
+
+	bufA, bufB := make([]byte, 64<<10), make([]byte, 64<<10)
+	for {
+		nA, errA := io.ReadFull(fileA, bufA)
+		nB, errB := io.ReadFull(fileB, bufB)
+		if !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return false, nil
+		}
+
+		endA := errors.Is(errA, io.EOF) || errors.Is(errA, io.ErrUnexpectedEOF)
+		endB := errors.Is(errB, io.EOF) || errors.Is(errB, io.ErrUnexpectedEOF)
+		switch {
+		case errA != nil && !endA:
+			return false, errA
+		case errB != nil && !endB:
+			return false, errB
+		case endA || endB:
+			return endA == endB, nil
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package dedup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/dupes"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T, files map[string]string) (string, dupes.Set) {
+	t.Helper()
+
+	base := t.TempDir()
+	set := dupes.Set{Size: 4, Hash: "hash"}
+	for _, name := range []string{"original", "copy", "changed"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+
+		path := filepath.Join(base, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		set.Paths = append(set.Paths, path)
+	}
+
+	return base, set
+}
+
+func fileID(t *testing.T, path string) utils.FileID {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat %s: %v", path, err)
+	}
+	id, _ := utils.GetFileID(info)
+
+	return id
+}
+
+func TestApply_DryRun(t *testing.T) {
+	t.Parallel()
+
+	base, set := setup(t, map[string]string{"original": "data", "copy": "data", "changed": "DATA"})
+
+	journal := &bytes.Buffer{}
+	actions, err := Apply([]dupes.Set{set}, Options{Method: MethodHardlink, Metadata: MetadataOriginal, DryRun: true, Journal: journal})
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, actions, 2)
+	assert.Empty(t, actions[0].Skipped)
+	assert.Equal(t, "content differs from the original", actions[1].Skipped)
+	assert.Equal(t, int64(4), Reclaimed(actions))
+	assert.Empty(t, journal.String(), "Expected nothing to be journaled in a dry run")
+	assert.NotEqual(t, fileID(t, filepath.Join(base, "original")), fileID(t, filepath.Join(base, "copy")))
+}
+
+func TestApply_InvalidPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, err := Apply(nil, Options{Method: MethodHardlink, Metadata: MetadataCopy})
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestApplyAndUndo_Hardlink(t *testing.T) {
+	t.Parallel()
+
+	base, set := setup(t, map[string]string{"original": "data", "copy": "data"})
+	original, duplicate := filepath.Join(base, "original"), filepath.Join(base, "copy")
+
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(duplicate, modTime, modTime); err != nil {
+		t.Fatalf("Failed to change times: %v", err)
+	}
+	if err := os.Chmod(duplicate, 0o640); err != nil {
+		t.Fatalf("Failed to change mode: %v", err)
+	}
+
+	journalPath := filepath.Join(t.TempDir(), "journal")
+	journal, err := os.Create(journalPath)
+	if err != nil {
+		t.Fatalf("Failed to create journal: %v", err)
+	}
+	defer journal.Close()
+
+	actions, err := Apply([]dupes.Set{set}, Options{Method: MethodHardlink, Metadata: MetadataOriginal, Journal: journal})
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, actions, 1)
+	assert.Equal(t, fileID(t, original), fileID(t, duplicate), "Expected the duplicate to be hardlinked")
+
+	actions, err = Undo(journalPath, false)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, actions, 1)
+	assert.Empty(t, actions[0].Skipped)
+	assert.NotEqual(t, fileID(t, original), fileID(t, duplicate), "Expected the duplicate to be an independent copy")
+
+	info, err := os.Stat(duplicate)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	assert.True(t, modTime.Equal(info.ModTime()), "Expected the modification time to be restored")
+
+	content, err := os.ReadFile(duplicate)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, "data", string(content))
+}
+
+func TestApply_Reflink(t *testing.T) {
+	t.Parallel()
+
+	base, set := setup(t, map[string]string{"original": "data", "copy": "data"})
+
+	actions, err := Apply([]dupes.Set{set}, Options{Method: MethodReflink, Metadata: MetadataCopy})
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, actions, 1)
+
+	content, err := os.ReadFile(filepath.Join(base, "copy"))
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, "data", string(content), "Expected the content to be kept whether or not reflinks are supported")
+}
@@ -0,0 +1,123 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Status describes how an item changed between two scans.
+type Status byte
+
+const (
+	StatusUnchanged Status = iota
+	StatusAdded
+	StatusRemoved
+	StatusChanged
+)
+
+// Node represents an item present in at least one of two compared trees.
+//
+// Fields:
+//   - Name: The name of the item.
+//   - Path: The path of the item in the newer tree, or in the older one if it was removed.
+//   - ItemType: The type of the item (e.g., file, directory).
+//   - OldSize: The size in bytes in the older tree (0 if added).
+//   - NewSize: The size in bytes in the newer tree (0 if removed).
+//   - Status: How the item changed.
+//   - Children: The compared children, sorted by absolute delta (largest first).
+type Node struct {
+	Name     string
+	Path     string
+	ItemType models.ItemType
+	OldSize  int64
+	NewSize  int64
+	Status   Status
+	Children []*Node
+}
+
+// Delta returns the size difference in bytes between the newer and the older tree.
+func (n *Node) Delta() int64 {
+	return n.NewSize - n.OldSize
+}
+
+// AbsDelta returns the absolute size difference in bytes.
+func (n *Node) AbsDelta() int64 {
+	if delta := n.Delta(); delta < 0 {
+		return -delta
+	}
+
+	return n.Delta()
+}
+
+// Compare builds a tree annotated with the size changes between before and after.
+// Children are matched by name and type, so an entry replaced by one of another
+// type is reported as removed and added.
+//
+// Parameters:
+//   - before: The root item of the older tree.
+//   - after: The root item of the newer tree.
+//
+// Returns:
+//
+//	The root node of the compared tree.
+func Compare(before, after *models.Item) *Node {
+	node := &Node{}
+
+	switch {
+	case before == nil:
+		node.Name, node.Path, node.ItemType = after.Name, after.Path, after.ItemType
+		node.NewSize = after.Bytes()
+		node.Status = StatusAdded
+	case after == nil:
+		node.Name, node.Path, node.ItemType = before.Name, before.Path, before.ItemType
+		node.OldSize = before.Bytes()
+		node.Status = StatusRemoved
+	default:
+		node.Name, node.Path, node.ItemType = after.Name, after.Path, after.ItemType
+		node.OldSize = before.Bytes()
+		node.NewSize = after.Bytes()
+		if node.OldSize != node.NewSize {
+			node.Status = StatusChanged
+		}
+	}
+
+	beforeChildren := map[key]*models.Item{}
+	if before != nil {
+		for _, child := range before.Children {
+			beforeChildren[key{child.Name, child.ItemType}] = child
+		}
+	}
+
+	if after != nil {
+		for _, child := range after.Children {
+			k := key{child.Name, child.ItemType}
+			node.Children = append(node.Children, Compare(beforeChildren[k], child))
+			delete(beforeChildren, k)
+		}
+	}
+
+	if before != nil {
+		for _, child := range before.Children {
+			if _, ok := beforeChildren[key{child.Name, child.ItemType}]; ok {
+				node.Children = append(node.Children, Compare(child, nil))
+			}
+		}
+	}
+
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if a.AbsDelta() != b.AbsDelta() {
+			return a.AbsDelta() > b.AbsDelta()
+		}
+
+		return a.Name < b.Name
+	})
+
+	return node
+}
+
+type key struct {
+	name     string
+	itemType models.ItemType
+}
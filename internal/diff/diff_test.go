@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func item(name string, itemType models.ItemType, size int64, children ...*models.Item) *models.Item {
+	i := models.NewItemWithSize(name, name, itemType, unit.NewFromBytes(size))
+	i.Children = append(i.Children, children...)
+
+	return i
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	before := item("root", models.ItemTypeDirectory, 110,
+		item("same", models.ItemTypeFile, 10),
+		item("removed", models.ItemTypeFile, 50),
+		item("logs", models.ItemTypeDirectory, 50,
+			item("app.log", models.ItemTypeFile, 50),
+		),
+	)
+	after := item("root", models.ItemTypeDirectory, 1070,
+		item("logs", models.ItemTypeDirectory, 1050,
+			item("app.log", models.ItemTypeFile, 1000),
+			item("new.log", models.ItemTypeFile, 50),
+		),
+		item("same", models.ItemTypeFile, 10),
+		item("added", models.ItemTypeFile, 10),
+	)
+
+	expected := &Node{
+		Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, OldSize: 110, NewSize: 1070, Status: StatusChanged,
+		Children: []*Node{
+			{
+				Name: "logs", Path: "logs", ItemType: models.ItemTypeDirectory, OldSize: 50, NewSize: 1050, Status: StatusChanged,
+				Children: []*Node{
+					{Name: "app.log", Path: "app.log", ItemType: models.ItemTypeFile, OldSize: 50, NewSize: 1000, Status: StatusChanged},
+					{Name: "new.log", Path: "new.log", ItemType: models.ItemTypeFile, NewSize: 50, Status: StatusAdded},
+				},
+			},
+			{Name: "removed", Path: "removed", ItemType: models.ItemTypeFile, OldSize: 50, Status: StatusRemoved},
+			{Name: "added", Path: "added", ItemType: models.ItemTypeFile, NewSize: 10, Status: StatusAdded},
+			{Name: "same", Path: "same", ItemType: models.ItemTypeFile, OldSize: 10, NewSize: 10, Status: StatusUnchanged},
+		},
+	}
+
+	assert.Equal(t, expected, Compare(before, after), "Compared tree does not match")
+}
+
+func TestCompare_TypeChange(t *testing.T) {
+	t.Parallel()
+
+	before := item("root", models.ItemTypeDirectory, 1, item("x", models.ItemTypeFile, 1))
+	after := item("root", models.ItemTypeDirectory, 2, item("x", models.ItemTypeDirectory, 2))
+
+	got := Compare(before, after)
+	assert.Len(t, got.Children, 2, "Expected the replaced entry to be reported twice")
+	assert.Equal(t, StatusAdded, got.Children[0].Status)
+	assert.Equal(t, StatusRemoved, got.Children[1].Status)
+}
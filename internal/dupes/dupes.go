@@ -0,0 +1,186 @@
+package dupes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+// PartialSize is the number of leading bytes hashed to cheaply rule out files of equal size.
+const PartialSize = 4 << 10
+
+// Set is a group of files with identical content.
+//
+// Fields:
+//   - Size: The size of each file in bytes.
+//   - Hash: The hex encoded SHA-256 hash of the content.
+//   - Paths: The sorted paths of the files, one per distinct inode.
+type Set struct {
+	Size  int64
+	Hash  string
+	Paths []string
+}
+
+// Wasted returns the number of bytes that could be reclaimed by keeping a single copy.
+func (s Set) Wasted() int64 {
+	return s.Size * int64(len(s.Paths)-1)
+}
+
+// Find searches the files of the scanned tree below root for duplicates.
+// Files are grouped by size, then by a hash of their first PartialSize bytes and finally
+// by the SHA-256 hash of their full content, so only files of equal size are ever read.
+// Files that are hardlinks of each other count as a single file, as they do not occupy
+// additional space. Empty files and anything that is not a regular file are ignored.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//   - minSize: The minimum size in bytes of files to consider.
+//
+// Returns:
+//   - []Set: The duplicate sets sorted by wasted bytes (largest first).
+//   - error: An error if a file cannot be read.
+func Find(root *models.Item, minSize int64) ([]Set, error) {
+	bySize := map[int64][]string{}
+	collect(root, minSize, bySize)
+
+	sets := []Set{}
+	for size, paths := range bySize {
+		if len(paths) < 2 {
+			continue
+		}
+
+		paths, err := distinct(paths)
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) < 2 {
+			continue
+		}
+
+		byPartial, err := group(paths, PartialSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for partial, candidates := range byPartial {
+			if len(candidates) < 2 {
+				continue
+			}
+
+			if size <= PartialSize {
+				sets = append(sets, Set{Size: size, Hash: partial, Paths: candidates})
+				continue
+			}
+
+			byFull, err := group(candidates, -1)
+			if err != nil {
+				return nil, err
+			}
+
+			for full, duplicates := range byFull {
+				if len(duplicates) >= 2 {
+					sets = append(sets, Set{Size: size, Hash: full, Paths: duplicates})
+				}
+			}
+		}
+	}
+
+	for _, set := range sets {
+		sort.Strings(set.Paths)
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Wasted() != sets[j].Wasted() {
+			return sets[i].Wasted() > sets[j].Wasted()
+		}
+
+		return sets[i].Hash < sets[j].Hash
+	})
+
+	return sets, nil
+}
+
+func collect(item *models.Item, minSize int64, bySize map[int64][]string) {
+	for _, child := range item.Children {
+		if child.ItemType == models.ItemTypeDirectory {
+			collect(child, minSize, bySize)
+		} else if child.Size != nil && child.Size.Size > 0 && child.Size.Size >= minSize {
+			bySize[child.Size.Size] = append(bySize[child.Size.Size], child.Path)
+		}
+	}
+}
+
+// distinct drops everything that is not a regular file and keeps a single path per inode.
+func distinct(paths []string) ([]string, error) {
+	seen := map[utils.FileID]struct{}{}
+	result := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		if id, ok := utils.GetFileID(info); ok {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+
+		result = append(result, path)
+	}
+
+	return result, nil
+}
+
+// group groups the paths by the hash of their first limit bytes, or of their full content if limit is negative.
+func group(paths []string, limit int64) (map[string][]string, error) {
+	groups := map[string][]string{}
+
+	for _, path := range paths {
+		hash, err := hashFile(path, limit)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		groups[hash] = append(groups[hash], path)
+	}
+
+	return groups, nil
+}
+
+func hashFile(path string, limit int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if limit >= 0 {
+		reader = io.LimitReader(file, limit)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
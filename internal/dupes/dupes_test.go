@@ -0,0 +1,66 @@
+package dupes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	large := strings.Repeat("x", PartialSize) + "end"
+	files := map[string]string{
+		"a/one.txt":       "same",
+		"b/two.txt":       "same",
+		"c/three.txt":     "same",
+		"other.txt":       "diff",
+		"large/first":     large,
+		"large/second":    large,
+		"large/different": strings.Repeat("x", PartialSize) + "END",
+		"empty/first":     "",
+		"empty/second":    "",
+	}
+	for path, content := range files {
+		full := filepath.Join(base, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	if err := os.Link(filepath.Join(base, "a/one.txt"), filepath.Join(base, "a/link.txt")); err != nil {
+		t.Fatalf("Failed to create hardlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(base, "other.txt"), filepath.Join(base, "symlink.txt")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	_, err := utils.WalkAndCollect(root, base, 0)
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	sets, err := Find(root, 0)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, sets, 2, "Expected two duplicate sets")
+
+	assert.Equal(t, int64(len(large)), sets[0].Size)
+	assert.Equal(t, []string{filepath.Join(base, "large/first"), filepath.Join(base, "large/second")}, sets[0].Paths)
+	assert.Equal(t, int64(len(large)), sets[0].Wasted())
+
+	assert.Equal(t, int64(4), sets[1].Size)
+	assert.Len(t, sets[1].Paths, 3, "Expected the hardlink to be counted once")
+	assert.Equal(t, int64(8), sets[1].Wasted())
+
+	sets, err = Find(root, 5)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Len(t, sets, 1, "Expected small files to be skipped")
+}
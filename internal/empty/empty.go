@@ -0,0 +1,161 @@
+package empty
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Result holds the empty items found by Find.
+//
+// Fields:
+//   - Files: The zero-byte files.
+//   - Directories: The directories containing nothing but empty directories, ordered
+//     bottom-up (every directory after the directories inside it).
+type Result struct {
+	Files       []*models.Item
+	Directories []*models.Item
+}
+
+// Removal describes the removal of an empty item.
+//
+// Fields:
+//   - Item: The removed item.
+//   - Skipped: The reason why the item was not removed, empty if it was (or would be in a dry run).
+type Removal struct {
+	Item    *models.Item
+	Skipped string
+}
+
+// Find searches the scanned tree below root for zero-byte files and for directories whose
+// recursive content is empty, using the aggregated sizes of the tree. A directory holding
+// zero-byte files is not empty, but the files are reported. The root itself is never reported.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//
+//	The empty files and directories.
+func Find(root *models.Item) Result {
+	result := Result{}
+	for _, child := range root.Children {
+		find(child, &result)
+	}
+
+	return result
+}
+
+// find collects the empty items below item and reports whether item is an empty directory.
+func find(item *models.Item, result *Result) bool {
+	if item.ItemType != models.ItemTypeDirectory {
+		if item.Size == nil || item.Size.Size == 0 {
+			result.Files = append(result.Files, item)
+		}
+		return false
+	}
+
+	// A directory with a non-zero aggregated size holds data, but may still contain empty items.
+	empty := item.Size == nil || item.Size.Size == 0
+	for _, child := range item.Children {
+		if !find(child, result) {
+			empty = false
+		}
+	}
+
+	if empty {
+		result.Directories = append(result.Directories, item)
+	}
+
+	return empty
+}
+
+// Remove deletes the empty files and then the empty directories bottom-up. Every item is
+// checked again right before it is removed: files must still be empty and directories are
+// removed with rmdir, so anything that received content since the scan is kept.
+//
+// Parameters:
+//   - result: The empty items as returned by Find.
+//   - dryRun: If set, the items are only checked but not removed.
+//
+// Returns:
+//   - []Removal: The removals in the order they were executed, including skipped ones.
+//   - error: An error if an item cannot be inspected or removed for another reason.
+func Remove(result Result, dryRun bool) ([]Removal, error) {
+	removals := make([]Removal, 0, len(result.Files)+len(result.Directories))
+
+	for _, file := range result.Files {
+		removal := Removal{Item: file}
+
+		info, err := os.Lstat(file.Path)
+		switch {
+		case os.IsNotExist(err):
+			removal.Skipped = "no longer exists"
+		case err != nil:
+			return removals, err
+		case info.Size() != 0:
+			removal.Skipped = "no longer empty"
+		case !dryRun:
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				return removals, err
+			}
+		}
+
+		removals = append(removals, removal)
+	}
+
+	for _, dir := range result.Directories {
+		removal := Removal{Item: dir}
+
+		entries, err := os.ReadDir(dir.Path)
+		switch {
+		case os.IsNotExist(err):
+			removal.Skipped = "no longer exists"
+		case err != nil:
+			return removals, err
+		case dryRun:
+			if !onlyRemoved(entries, dir, removals) {
+				removal.Skipped = "no longer empty"
+			}
+		default:
+			if err := os.Remove(dir.Path); err != nil {
+				if !errors.Is(err, syscall.ENOTEMPTY) && !errors.Is(err, syscall.EEXIST) {
+					return removals, err
+				}
+				removal.Skipped = "no longer empty"
+			}
+		}
+
+		removals = append(removals, removal)
+	}
+
+	return removals, nil
+}
+
+// onlyRemoved reports whether all entries of dir are going to be removed, as a dry run
+// cannot rely on rmdir failing for directories that would not be empty.
+func onlyRemoved(entries []os.DirEntry, dir *models.Item, removals []Removal) bool {
+	removed := map[string]struct{}{}
+	for _, removal := range removals {
+		if removal.Skipped == "" {
+			removed[removal.Item.Path] = struct{}{}
+		}
+	}
+
+	for _, entry := range entries {
+		found := false
+		for _, child := range dir.Children {
+			if child.Name == entry.Name() {
+				_, found = removed[child.Path]
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package empty
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T) (string, *models.Item) {
+	t.Helper()
+
+	base := t.TempDir()
+	for _, dir := range []string{"empty/nested/deeper", "data/empty"} {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+	for path, content := range map[string]string{"data/file": "content", "data/zero": "", "zero": ""} {
+		if err := os.WriteFile(filepath.Join(base, path), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	if _, err := utils.WalkAndCollect(root, base, 0); err != nil {
+		t.Fatalf("Failed to scan: %v", err)
+	}
+
+	return base, root
+}
+
+func paths(items []*models.Item) []string {
+	result := []string{}
+	for _, item := range items {
+		result = append(result, item.Path)
+	}
+
+	return result
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	base, root := setup(t)
+	result := Find(root)
+
+	assert.Equal(t, []string{filepath.Join(base, "data/zero"), filepath.Join(base, "zero")}, paths(result.Files))
+	assert.Equal(t, []string{
+		filepath.Join(base, "data/empty"),
+		filepath.Join(base, "empty/nested/deeper"),
+		filepath.Join(base, "empty/nested"),
+		filepath.Join(base, "empty"),
+	}, paths(result.Directories))
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	base, root := setup(t)
+	result := Find(root)
+
+	if err := os.WriteFile(filepath.Join(base, "empty/nested/new"), []byte("new"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		removals, err := Remove(result, dryRun)
+		assert.NoError(t, err, "Unexpected error occurred")
+
+		skipped := map[string]string{}
+		for _, removal := range removals {
+			if removal.Skipped != "" {
+				skipped[removal.Item.Path] = removal.Skipped
+			}
+		}
+		assert.Equal(t, map[string]string{
+			filepath.Join(base, "empty/nested"): "no longer empty",
+			filepath.Join(base, "empty"):        "no longer empty",
+		}, skipped, "Skipped removals do not match (dry run: %v)", dryRun)
+	}
+
+	for path, exists := range map[string]bool{
+		"zero": false, "data/zero": false, "data/empty": false, "empty/nested/deeper": false,
+		"empty/nested/new": true, "data/file": true,
+	} {
+		_, err := os.Stat(filepath.Join(base, path))
+		assert.Equal(t, exists, err == nil, "Unexpected existence of %s", path)
+	}
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+// DUUnit is the way sizes are written by DU.
+type DUUnit string
+
+const (
+	// DUUnitBlocks writes sizes as 1024-byte blocks, rounded up, like du without options.
+	DUUnitBlocks DUUnit = "blocks"
+	// DUUnitHuman writes sizes with a unit suffix (e.g. 4.0K, 12M), like du -h.
+	DUUnitHuman DUUnit = "human"
+	// DUUnitBytes writes sizes in bytes, like du -b.
+	DUUnitBytes DUUnit = "bytes"
+)
+
+// DU writes entries in the output format of du, a size and the path separated by a tab on each line.
+// As du, it writes directories after their content. Sizes are apparent sizes (like du --apparent-size),
+// since the disk usage is not known. Its Write method can be passed to utils.Stream.
+type DU struct {
+	w    io.Writer
+	unit DUUnit
+	opts Options
+}
+
+// NewDU creates a new DU writer.
+//
+// Parameters:
+//   - w: The writer the lines are written to.
+//   - unit: The way sizes are written.
+//   - opts: The options selecting the written entries. The entry at depth 0 is always written, so
+//     a Depth of -1 writes only the total, like du --max-depth=0.
+//
+// Returns:
+//   - *DU: A new DU writer.
+func NewDU(w io.Writer, unit DUUnit, opts Options) *DU {
+	return &DU{w: w, unit: unit, opts: opts}
+}
+
+// Write writes the entry as a single line, if it passes the options.
+//
+// Parameters:
+//   - entry: The entry to write, as reported by utils.Stream.
+//
+// Returns:
+//   - error: An error if writing fails.
+func (d *DU) Write(entry utils.Entry) error {
+	var size int64
+	if entry.Size != nil {
+		size = entry.Size.Size
+	}
+
+	if entry.Depth > 0 && !d.opts.include(entry.ItemType, size, entry.Depth-1) {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(d.w, "%s\t%s\n", d.format(size), entry.Path)
+
+	return err
+}
+
+// WriteTree writes all items of an already scanned tree in the order utils.Stream reports them.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - error: An error if writing fails.
+func (d *DU) WriteTree(root *models.Item) error {
+	return d.writeItem(root, 0)
+}
+
+func (d *DU) writeItem(item *models.Item, depth int) error {
+	for _, child := range item.Children {
+		if err := d.writeItem(child, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return d.Write(utils.Entry{Name: item.Name, Path: item.Path, ItemType: item.ItemType, Size: item.Size, Depth: depth})
+}
+
+func (d *DU) format(size int64) string {
+	switch d.unit {
+	case DUUnitBytes:
+		return fmt.Sprint(size)
+	case DUUnitHuman:
+		return humanSize(size)
+	default:
+		return fmt.Sprint((size + 1023) / 1024)
+	}
+}
+
+// humanSize formats size like du -h: bytes without suffix below 1K, one decimal below 10 and
+// whole numbers otherwise, always rounded up.
+func humanSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprint(size)
+	}
+
+	value := float64(size)
+	suffix := ""
+	for _, s := range []string{"K", "M", "G", "T", "P", "E"} {
+		value /= 1024
+		suffix = s
+		if math.Ceil(value) < 1024 {
+			break
+		}
+	}
+
+	if tenths := math.Ceil(value*10) / 10; tenths < 10 {
+		return fmt.Sprintf("%.1f%s", tenths, suffix)
+	}
+
+	return fmt.Sprintf("%.0f%s", math.Ceil(value), suffix)
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDU_WriteTree(t *testing.T) {
+	t.Parallel()
+
+	zero, minusOne := 0, -1
+
+	tests := []struct {
+		name string
+		unit DUUnit
+		opts Options
+		want string
+	}{
+		{
+			name: "Blocks bottom-up",
+			unit: DUUnitBlocks,
+			opts: Options{},
+			want: "1\troot/small\n2\troot/dir/big\n2\troot/dir\n3\troot\n",
+		},
+		{
+			name: "Bytes of directories only",
+			unit: DUUnitBytes,
+			opts: Options{DirectoryOnly: true},
+			want: "2000\troot/dir\n3000\troot\n",
+		},
+		{
+			name: "Human sizes with max depth 1",
+			unit: DUUnitHuman,
+			opts: Options{Depth: &zero},
+			want: "1000\troot/small\n2.0K\troot/dir\n3.0K\troot\n",
+		},
+		{
+			name: "Summary with max depth 0",
+			unit: DUUnitBytes,
+			opts: Options{Depth: &minusOne},
+			want: "3000\troot\n",
+		},
+		{
+			name: "Threshold",
+			unit: DUUnitBytes,
+			opts: Options{Threshold: unit.NewFromBytes(2000)},
+			want: "2000\troot/dir/big\n2000\troot/dir\n3000\troot\n",
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, NewDU(&buf, tt.unit, tt.opts).WriteTree(testTree()))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestHumanSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{size: 0, want: "0"},
+		{size: 1023, want: "1023"},
+		{size: 1024, want: "1.0K"},
+		{size: 1025, want: "1.1K"},
+		{size: 10 * 1024, want: "10K"},
+		{size: 10*1024 + 1, want: "11K"},
+		{size: 1024*1024 - 1, want: "1.0M"},
+		{size: 5 << 30, want: "5.0G"},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, humanSize(tt.size))
+		})
+	}
+}
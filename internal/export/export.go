@@ -0,0 +1,45 @@
+package export
+
+import (
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+// Options selects the items written by the exporters, mirroring the filters of print.Tree.
+//
+// Fields:
+//   - DirectoryOnly: If set, files are left out.
+//   - Depth: The maximum depth of exported items below the root (0 exports only the children of the root). Nil for no limit.
+//   - Threshold: The minimum size of exported items. Nil for no threshold.
+type Options struct {
+	DirectoryOnly bool
+	Depth         *int
+	Threshold     *unit.Size
+}
+
+// include reports whether an item of the given type and size, located at depth below the root
+// (0 for the children of the root), is exported. As a directory is at least as large as its content,
+// its children are never exported if it is not.
+func (o Options) include(itemType models.ItemType, size int64, depth int) bool {
+	if o.Depth != nil && depth > *o.Depth {
+		return false
+	}
+
+	if o.DirectoryOnly && itemType != models.ItemTypeDirectory {
+		return false
+	}
+
+	return o.Threshold == nil || o.Threshold.Size <= size
+}
+
+// children returns the children of item, which are located at depth below the root, that are exported.
+func (o Options) children(item *models.Item, depth int) []*models.Item {
+	children := make([]*models.Item, 0, len(item.Children))
+	for _, child := range item.Children {
+		if o.include(child.ItemType, child.Bytes(), depth) {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// frameReplacer removes the characters separating frames and lines from names.
+var frameReplacer = strings.NewReplacer(";", "_", "\n", "_", "\r", "_")
+
+// Folded writes the tree below root as folded stacks, one "root;dir;subdir;file size" line per file,
+// which flame graph tools (flamegraph.pl, speedscope, Grafana) render as a zoomable flame graph.
+// Items left out by the options are not dropped but added to their directory, which then gets its own
+// line with the size not covered by its written content, so all lines add up to the size of the root.
+// Semicolons and line breaks in names are replaced by underscores.
+//
+// Parameters:
+//   - w: The writer the stacks are written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the items written as their own frames.
+//
+// Returns:
+//   - error: An error if writing fails.
+func Folded(w io.Writer, root *models.Item, opts Options) error {
+	out := bufio.NewWriter(w)
+	writeFolded(out, root, "", opts, 0)
+
+	return out.Flush()
+}
+
+// writeFolded writes the stacks of item, whose children are located at depth below the root, and returns the written size.
+func writeFolded(out *bufio.Writer, item *models.Item, stack string, opts Options, depth int) int64 {
+	frame := frameReplacer.Replace(item.Name)
+	if stack != "" {
+		frame = stack + ";" + frame
+	}
+
+	var written int64
+	if item.ItemType == models.ItemTypeDirectory {
+		for _, child := range opts.children(item, depth) {
+			written += writeFolded(out, child, frame, opts, depth+1)
+		}
+	}
+
+	if rest := item.Bytes() - written; rest > 0 {
+		fmt.Fprintf(out, "%s %d\n", frame, rest)
+		written += rest
+	}
+
+	return written
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolded(t *testing.T) {
+	t.Parallel()
+
+	zero := 0
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "One stack per file",
+			opts: Options{},
+			want: "root;small 1000\nroot;dir;big 2000\n",
+		},
+		{
+			name: "Files of hidden items are added to their directory",
+			opts: Options{DirectoryOnly: true},
+			want: "root;dir 2000\nroot 1000\n",
+		},
+		{
+			name: "Depth limit",
+			opts: Options{Depth: &zero},
+			want: "root;small 1000\nroot;dir 2000\n",
+		},
+		{
+			name: "Threshold",
+			opts: Options{Threshold: unit.NewFromBytes(1500)},
+			want: "root;dir;big 2000\nroot 1000\n",
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, Folded(&buf, testTree(), tt.opts))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestFolded_EscapesNames(t *testing.T) {
+	t.Parallel()
+
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(5))
+	root.Children = append(root.Children,
+		models.NewItemWithSize("a;b\nc", "root/a;b\nc", models.ItemTypeFile, unit.NewFromBytes(5)),
+		models.NewItemWithSize("empty", "root/empty", models.ItemTypeFile, unit.NewFromBytes(0)))
+
+	var buf bytes.Buffer
+	require.NoError(t, Folded(&buf, root, Options{}))
+	assert.Equal(t, "root;a_b_c 5\n", buf.String())
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+//go:embed report
+var reportFS embed.FS
+
+var reportTemplate = template.Must(template.ParseFS(reportFS, "report/report.html"))
+
+// HTML writes the tree below root as a single self-contained HTML report with a zoomable squarified
+// treemap and a collapsible tree table. Styles, scripts and the data are embedded, so the report
+// can be viewed offline. The data has the schema of JSONNode.
+//
+// Parameters:
+//   - w: The writer the report is written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the items in the report. The root is always included.
+//
+// Returns:
+//   - error: An error if writing fails.
+func HTML(w io.Writer, root *models.Item, opts Options) error {
+	css, err := reportFS.ReadFile("report/report.css")
+	if err != nil {
+		return err
+	}
+
+	js, err := reportFS.ReadFile("report/report.js")
+	if err != nil {
+		return err
+	}
+
+	return reportTemplate.Execute(w, struct {
+		Root        *JSONNode
+		GeneratedAt string
+		CSS         template.CSS
+		JS          template.JS
+	}{
+		Root:        jsonNode(root, opts, 0),
+		GeneratedAt: time.Now().Format(time.RFC1123),
+		CSS:         template.CSS(css),
+		JS:          template.JS(js),
+	})
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	root := testTree()
+	root.Children = append(root.Children, models.NewItemWithSize("</script><b>", "root/</script><b>", models.ItemTypeFile, nil))
+
+	var buf bytes.Buffer
+	require.NoError(t, HTML(&buf, root, Options{}))
+
+	report := buf.String()
+	assert.Contains(t, report, "<title>MemSpace report: root</title>")
+	assert.Contains(t, report, `"path":"root/dir/big"`)
+	assert.Contains(t, report, "function squarify(")
+	assert.Contains(t, report, "#treemap {")
+	assert.NotContains(t, report, "</script><b>", "Names must be escaped")
+	assert.NotContains(t, report, "http://", "The report must not load external resources")
+	assert.NotContains(t, report, "https://", "The report must not load external resources")
+}
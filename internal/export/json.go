@@ -0,0 +1,88 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+// JSONSchemaVersion is the version of the schema written by JSON. It is only increased on
+// incompatible changes; new fields may be added without changing it.
+const JSONSchemaVersion = 1
+
+// JSONDocument is the top-level object written by JSON.
+//
+//	{
+//	  "schema_version": 1,
+//	  "root": <node>
+//	}
+type JSONDocument struct {
+	SchemaVersion int       `json:"schema_version"`
+	Root          *JSONNode `json:"root"`
+}
+
+// JSONNode is a file or directory written by JSON.
+//
+//	{
+//	  "name": "nginx",             // base name of the item
+//	  "path": "/var/log/nginx",    // path as scanned (relative if the base path was relative)
+//	  "type": "directory",         // "directory" or "file"
+//	  "size": 1024,                // size in bytes, for directories the total of their content
+//	  "human_size": "1.00KB",      // size formatted like the tree output
+//	  "children": [<node>, ...]    // directories only, the exported children in scan order
+//	}
+//
+// The children of a directory are omitted (not empty) if the directory is at the depth limit.
+type JSONNode struct {
+	Name      string      `json:"name"`
+	Path      string      `json:"path"`
+	Type      string      `json:"type"`
+	Size      int64       `json:"size"`
+	HumanSize string      `json:"human_size"`
+	Children  *[]JSONNode `json:"children,omitempty"`
+}
+
+// JSON writes the tree below root as an indented JSON document (see JSONDocument and JSONNode).
+// The root is always written, its descendants only if they pass the options.
+//
+// Parameters:
+//   - w: The writer the document is written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the exported items.
+//
+// Returns:
+//   - error: An error if writing fails.
+func JSON(w io.Writer, root *models.Item, opts Options) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Root:          jsonNode(root, opts, 0),
+	})
+}
+
+// jsonNode converts item, whose children are located at depth below the root.
+func jsonNode(item *models.Item, opts Options, depth int) *JSONNode {
+	node := &JSONNode{
+		Name:      item.Name,
+		Path:      item.Path,
+		Type:      item.ItemType.String(),
+		Size:      item.Bytes(),
+		HumanSize: unit.NewFromBytes(item.Bytes()).RawSizeString(),
+	}
+
+	if item.ItemType != models.ItemTypeDirectory || (opts.Depth != nil && depth > *opts.Depth) {
+		return node
+	}
+
+	children := []JSONNode{}
+	for _, child := range opts.children(item, depth) {
+		children = append(children, *jsonNode(child, opts, depth+1))
+	}
+	node.Children = &children
+
+	return node
+}
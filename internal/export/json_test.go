@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTree builds root (3000) with the file small (1000) and the directory dir (2000),
+// which contains the file big (2000).
+func testTree() *models.Item {
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(3000))
+	root.Root = true
+	dir := models.NewItemWithSize("dir", "root/dir", models.ItemTypeDirectory, unit.NewFromBytes(2000))
+	dir.Children = append(dir.Children, models.NewItemWithSize("big", "root/dir/big", models.ItemTypeFile, unit.NewFromBytes(2000)))
+	root.Children = append(root.Children, models.NewItemWithSize("small", "root/small", models.ItemTypeFile, unit.NewFromBytes(1000)), dir)
+
+	return root
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	depth := 0
+	big := JSONNode{Name: "big", Path: "root/dir/big", Type: "file", Size: 2000, HumanSize: unit.NewFromBytes(2000).RawSizeString()}
+	small := JSONNode{Name: "small", Path: "root/small", Type: "file", Size: 1000, HumanSize: unit.NewFromBytes(1000).RawSizeString()}
+	dir := func(children ...JSONNode) JSONNode {
+		node := JSONNode{Name: "dir", Path: "root/dir", Type: "directory", Size: 2000, HumanSize: unit.NewFromBytes(2000).RawSizeString()}
+		if children != nil {
+			node.Children = &children
+		}
+		return node
+	}
+	root := func(children ...JSONNode) *JSONNode {
+		if children == nil {
+			children = []JSONNode{}
+		}
+		return &JSONNode{Name: "root", Path: "root", Type: "directory", Size: 3000, HumanSize: unit.NewFromBytes(3000).RawSizeString(), Children: &children}
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want *JSONNode
+	}{
+		{
+			name: "Full tree",
+			opts: Options{},
+			want: root(small, dir(big)),
+		},
+		{
+			name: "Directories only",
+			opts: Options{DirectoryOnly: true},
+			want: root(dir([]JSONNode{}...)),
+		},
+		{
+			name: "Depth limit",
+			opts: Options{Depth: &depth},
+			want: root(small, dir()),
+		},
+		{
+			name: "Threshold",
+			opts: Options{Threshold: unit.NewFromBytes(1500)},
+			want: root(dir(big)),
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, JSON(&buf, testTree(), tt.opts))
+
+			var document JSONDocument
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+			assert.Equal(t, JSONSchemaVersion, document.SchemaVersion)
+			assert.Equal(t, tt.want, document.Root)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+// NDJSONRecord is a file or directory written by NDJSON as a single line.
+//
+//	{"name":"nginx","path":"/var/log/nginx","type":"directory","size":1024,"human_size":"1.00KB","depth":1}
+//
+// The fields match JSONNode, depth is the depth below the base path, which itself has depth 0.
+// Directories are written after their content, so their size is complete and the base path is the last record.
+type NDJSONRecord struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+	HumanSize string `json:"human_size"`
+	Depth     int    `json:"depth"`
+}
+
+// NDJSON writes entries as newline-delimited JSON (see NDJSONRecord), one object per line.
+// Its Write method can be passed to utils.Stream, so records are written while scanning.
+type NDJSON struct {
+	encoder *json.Encoder
+	opts    Options
+}
+
+// NewNDJSON creates a new NDJSON writer.
+//
+// Parameters:
+//   - w: The writer the records are written to.
+//   - opts: The options selecting the written entries. The entry at depth 0 is always written.
+//
+// Returns:
+//   - *NDJSON: A new NDJSON writer.
+func NewNDJSON(w io.Writer, opts Options) *NDJSON {
+	return &NDJSON{encoder: json.NewEncoder(w), opts: opts}
+}
+
+// Write writes the entry as a single line, if it passes the options.
+//
+// Parameters:
+//   - entry: The entry to write, as reported by utils.Stream.
+//
+// Returns:
+//   - error: An error if writing fails.
+func (n *NDJSON) Write(entry utils.Entry) error {
+	var size int64
+	if entry.Size != nil {
+		size = entry.Size.Size
+	}
+
+	if entry.Depth > 0 && !n.opts.include(entry.ItemType, size, entry.Depth-1) {
+		return nil
+	}
+
+	return n.encoder.Encode(NDJSONRecord{
+		Name:      entry.Name,
+		Path:      entry.Path,
+		Type:      entry.ItemType.String(),
+		Size:      size,
+		HumanSize: unit.NewFromBytes(size).RawSizeString(),
+		Depth:     entry.Depth,
+	})
+}
+
+// WriteTree writes all items of an already scanned tree in the order utils.Stream reports them.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - error: An error if writing fails.
+func (n *NDJSON) WriteTree(root *models.Item) error {
+	return n.writeItem(root, 0)
+}
+
+func (n *NDJSON) writeItem(item *models.Item, depth int) error {
+	for _, child := range item.Children {
+		if err := n.writeItem(child, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return n.Write(utils.Entry{Name: item.Name, Path: item.Path, ItemType: item.ItemType, Size: item.Size, Depth: depth})
+}
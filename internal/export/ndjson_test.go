@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeNDJSON(t *testing.T, data []byte) []NDJSONRecord {
+	t.Helper()
+
+	records := []NDJSONRecord{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record NDJSONRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNDJSON_WriteTree(t *testing.T) {
+	t.Parallel()
+
+	depth := 0
+	record := func(name, path, itemType string, size int64, depth int) NDJSONRecord {
+		return NDJSONRecord{Name: name, Path: path, Type: itemType, Size: size, HumanSize: unit.NewFromBytes(size).RawSizeString(), Depth: depth}
+	}
+	small := record("small", "root/small", "file", 1000, 1)
+	big := record("big", "root/dir/big", "file", 2000, 2)
+	dir := record("dir", "root/dir", "directory", 2000, 1)
+	root := record("root", "root", "directory", 3000, 0)
+
+	tests := []struct {
+		name string
+		opts Options
+		want []NDJSONRecord
+	}{
+		{
+			name: "Full tree in post-order",
+			opts: Options{},
+			want: []NDJSONRecord{small, big, dir, root},
+		},
+		{
+			name: "Directories only",
+			opts: Options{DirectoryOnly: true},
+			want: []NDJSONRecord{dir, root},
+		},
+		{
+			name: "Depth limit",
+			opts: Options{Depth: &depth},
+			want: []NDJSONRecord{small, dir, root},
+		},
+		{
+			name: "Threshold",
+			opts: Options{Threshold: unit.NewFromBytes(1500)},
+			want: []NDJSONRecord{big, dir, root},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, NewNDJSON(&buf, tt.opts).WriteTree(testTree()))
+			assert.Equal(t, tt.want, decodeNDJSON(t, buf.Bytes()))
+		})
+	}
+}
+
+func TestNDJSON_Write(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(base, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "file"), make([]byte, 10), 0o644))
+
+	var buf bytes.Buffer
+	_, err := utils.Stream(base, NewNDJSON(&buf, Options{}).Write)
+	require.NoError(t, err)
+
+	records := decodeNDJSON(t, buf.Bytes())
+	require.Len(t, records, 3)
+	assert.Equal(t, filepath.Join(base, "dir", "file"), records[0].Path)
+	assert.Equal(t, int64(10), records[0].Size)
+	assert.Equal(t, 2, records[0].Depth)
+	assert.Equal(t, filepath.Join(base, "dir"), records[1].Path)
+	assert.Equal(t, "directory", records[1].Type)
+	assert.Equal(t, base, records[2].Path)
+	assert.Equal(t, 0, records[2].Depth)
+}
@@ -0,0 +1,223 @@
+"use strict";
+
+// Height of the label of a directory whose content is drawn inside it.
+const HEADER = 16;
+// Directories smaller than this (in pixels) do not show their content.
+const MIN_NESTED = 40;
+
+const treemap = document.getElementById("treemap");
+const breadcrumb = document.getElementById("breadcrumb");
+const tbody = document.querySelector("#tree tbody");
+
+// Parent links are needed to zoom out and to build the breadcrumb.
+(function link(node, parent) {
+  node.parent = parent;
+  (node.children || []).sort((a, b) => b.size - a.size).forEach((child) => link(child, node));
+})(report, null);
+
+let current = report;
+
+function color(node, depth) {
+  if (node.type === "file") {
+    return `hsl(210, 15%, ${82 - Math.min(depth, 6) * 3}%)`;
+  }
+  let hash = 0;
+  for (const c of node.name) {
+    hash = (hash * 31 + c.charCodeAt(0)) | 0;
+  }
+  return `hsl(${Math.abs(hash) % 360}, 55%, ${75 - Math.min(depth, 6) * 4}%)`;
+}
+
+// worst returns the highest aspect ratio of the rectangles of a row along a side of the given length.
+function worst(row, side) {
+  let sum = 0, max = 0, min = Infinity;
+  for (const item of row) {
+    sum += item.area;
+    max = Math.max(max, item.area);
+    min = Math.min(min, item.area);
+  }
+  return Math.max((side * side * max) / (sum * sum), (sum * sum) / (side * side * min));
+}
+
+// squarify lays out the items (sorted by area, descending) in the rectangle with the squarified
+// treemap algorithm of Bruls, Huizing and van Wijk and returns one rectangle per item.
+function squarify(items, x, y, w, h) {
+  const rects = [];
+  let row = [];
+
+  const place = () => {
+    const sum = row.reduce((total, item) => total + item.area, 0);
+    if (w >= h) {
+      const width = sum / h;
+      let offset = y;
+      for (const item of row) {
+        const height = item.area / width;
+        rects.push({ node: item.node, x, y: offset, w: width, h: height });
+        offset += height;
+      }
+      x += width;
+      w -= width;
+    } else {
+      const height = sum / w;
+      let offset = x;
+      for (const item of row) {
+        const width = item.area / height;
+        rects.push({ node: item.node, x: offset, y, w: width, h: height });
+        offset += width;
+      }
+      y += height;
+      h -= height;
+    }
+    row = [];
+  };
+
+  for (const item of items) {
+    const side = Math.min(w, h);
+    if (row.length > 0 && worst(row.concat([item]), side) > worst(row, side)) {
+      place();
+    }
+    row.push(item);
+  }
+  if (row.length > 0) {
+    place();
+  }
+
+  return rects;
+}
+
+// draw renders the children of node into the rectangle. Directories large enough show their content as well.
+function draw(node, x, y, w, h, depth) {
+  const children = (node.children || []).filter((child) => child.size > 0);
+  if (node.size <= 0 || children.length === 0 || w <= 0 || h <= 0) {
+    return;
+  }
+
+  // Items hidden by the filters leave their share of the rectangle empty.
+  const scale = (w * h) / node.size;
+  const items = children.map((child) => ({ node: child, area: child.size * scale }));
+
+  for (const rect of squarify(items, x, y, w, h)) {
+    const cell = document.createElement("div");
+    cell.className = `cell ${rect.node.type}`;
+    cell.style.left = `${rect.x}px`;
+    cell.style.top = `${rect.y}px`;
+    cell.style.width = `${rect.w}px`;
+    cell.style.height = `${rect.h}px`;
+    cell.style.background = color(rect.node, depth);
+    cell.title = `${rect.node.path}\n${rect.node.human_size}`;
+
+    if (rect.w > 30 && rect.h > 14) {
+      const label = document.createElement("div");
+      label.className = "label";
+      label.textContent = `${rect.node.name} ${rect.node.human_size}`;
+      cell.appendChild(label);
+    }
+
+    if (rect.node.type === "directory") {
+      cell.addEventListener("click", (event) => {
+        event.stopPropagation();
+        zoom(rect.node);
+      });
+    }
+    treemap.appendChild(cell);
+
+    if (rect.node.type === "directory" && rect.w > MIN_NESTED && rect.h > MIN_NESTED) {
+      draw(rect.node, rect.x + 2, rect.y + HEADER, rect.w - 4, rect.h - HEADER - 2, depth + 1);
+    }
+  }
+}
+
+// zoom shows node in the treemap and updates the breadcrumb.
+function zoom(node) {
+  current = node;
+  treemap.replaceChildren();
+  draw(node, 0, 0, treemap.clientWidth, treemap.clientHeight, 0);
+
+  const lineage = [];
+  for (let n = node; n; n = n.parent) {
+    lineage.unshift(n);
+  }
+  breadcrumb.replaceChildren();
+  lineage.forEach((n, i) => {
+    if (i > 0) {
+      breadcrumb.append(" / ");
+    }
+    const link = document.createElement("a");
+    link.textContent = i === 0 ? n.path : n.name;
+    link.addEventListener("click", () => zoom(n));
+    breadcrumb.appendChild(link);
+  });
+  breadcrumb.append(` (${node.human_size})`);
+}
+
+// row creates the table row of node, which is indented by its depth.
+function row(node, depth) {
+  const tr = document.createElement("tr");
+  tr.dataset.depth = depth;
+
+  const name = document.createElement("td");
+  name.className = "name";
+  name.style.paddingLeft = `${depth * 1.2 + 0.4}em`;
+  const toggle = document.createElement("span");
+  toggle.className = "toggle";
+  if (node.children && node.children.length > 0) {
+    toggle.textContent = "▸";
+    toggle.addEventListener("click", () => {
+      if (toggle.textContent === "▸") {
+        toggle.textContent = "▾";
+        expand(tr, node, depth);
+      } else {
+        toggle.textContent = "▸";
+        collapse(tr, depth);
+      }
+    });
+  }
+  name.appendChild(toggle);
+  name.append(`${node.type === "directory" ? "📁" : "📄"} ${node.name}`);
+  if (node.type === "directory") {
+    name.style.cursor = "zoom-in";
+    name.addEventListener("dblclick", () => zoom(node));
+  }
+
+  const size = document.createElement("td");
+  size.className = "size";
+  size.textContent = node.human_size;
+
+  const share = document.createElement("td");
+  share.className = "share";
+  const percent = node.parent && node.parent.size > 0 ? (node.size / node.parent.size) * 100 : 100;
+  share.textContent = `${percent.toFixed(1)}%`;
+  const bar = document.createElement("span");
+  bar.className = "bar";
+  const fill = document.createElement("span");
+  fill.style.width = `${percent}%`;
+  bar.appendChild(fill);
+  share.appendChild(bar);
+
+  tr.append(name, size, share);
+  return tr;
+}
+
+// expand inserts the rows of the children of node after its row tr.
+function expand(tr, node, depth) {
+  let after = tr;
+  for (const child of node.children) {
+    const childRow = row(child, depth + 1);
+    after.after(childRow);
+    after = childRow;
+  }
+}
+
+// collapse removes all rows below the row tr of a node at depth.
+function collapse(tr, depth) {
+  while (tr.nextElementSibling && Number(tr.nextElementSibling.dataset.depth) > depth) {
+    tr.nextElementSibling.remove();
+  }
+}
+
+const rootRow = row(report, 0);
+tbody.appendChild(rootRow);
+rootRow.querySelector(".toggle").click();
+
+zoom(report);
+window.addEventListener("resize", () => zoom(current));
@@ -0,0 +1,249 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+
+	"github.com/StevenCyb/MemSpace/internal/layout"
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+// SVGChart is the kind of chart drawn by SVG.
+type SVGChart string
+
+const (
+	// SVGChartTreemap draws nested rectangles with areas proportional to the sizes.
+	SVGChartTreemap SVGChart = "treemap"
+	// SVGChartSunburst draws rings of arcs around the root, one ring per depth, with angles proportional to the sizes.
+	SVGChartSunburst SVGChart = "sunburst"
+)
+
+// SVGColor is the way SVG colors the items.
+type SVGColor string
+
+const (
+	// SVGColorType colors directories blue, getting darker with depth, and files orange.
+	SVGColorType SVGColor = "type"
+	// SVGColorDepth gives every depth its own hue.
+	SVGColorDepth SVGColor = "depth"
+)
+
+const (
+	// svgHeader is the height of the title line above the chart.
+	svgHeader = 24
+	// svgLabel is the height reserved for the label of a directory whose content is drawn inside it.
+	svgLabel = 16
+	// svgCharWidth is the estimated width of a character of a label, used to shorten labels that do not fit.
+	svgCharWidth = 7
+)
+
+// SVGOptions configures the image written by SVG.
+//
+// Fields:
+//   - Chart: The kind of chart.
+//   - Color: The way items are colored.
+//   - Width, Height: The size of the image in pixels.
+type SVGOptions struct {
+	Chart  SVGChart
+	Color  SVGColor
+	Width  int
+	Height int
+}
+
+// SVG draws the tree below root as a treemap or sunburst and writes it as an SVG image.
+// Items are labeled with name and size where there is room for it and carry their path and size
+// as tooltip. Items too small to be visible are left out.
+//
+// Parameters:
+//   - w: The writer the image is written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the drawn items. Depth and Threshold keep large trees legible.
+//   - svg: The options configuring the image.
+//
+// Returns:
+//   - error: An error if writing fails.
+func SVG(w io.Writer, root *models.Item, opts Options, svg SVGOptions) error {
+	out := bufio.NewWriter(w)
+	width, height := float64(svg.Width), float64(svg.Height)
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		svg.Width, svg.Height, svg.Width, svg.Height)
+	fmt.Fprintf(out, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(out, `<text x="4" y="16" font-size="14" font-weight="bold">%s</text>`+"\n",
+		html.EscapeString(fmt.Sprintf("%s [%s]", root.Path, unit.NewFromBytes(root.Bytes()).RawSizeString())))
+
+	chart := layout.Rect{Y: svgHeader, W: width, H: height - svgHeader}.Inset(2, 0, 2, 2)
+	if svg.Chart == SVGChartSunburst {
+		drawSunburst(out, root, opts, svg.Color, chart)
+	} else {
+		drawTreemap(out, root, opts, svg.Color, chart, 0)
+	}
+
+	out.WriteString("</svg>\n")
+
+	return out.Flush()
+}
+
+// drawTreemap draws the children of item, which are located at depth below the root, into bounds.
+func drawTreemap(out *bufio.Writer, item *models.Item, opts Options, color SVGColor, bounds layout.Rect, depth int) {
+	children := opts.children(item, depth)
+	weights := make([]float64, len(children))
+	for i, child := range children {
+		weights[i] = float64(child.Bytes())
+	}
+
+	for i, rect := range layout.Squarify(weights, float64(item.Bytes()), bounds) {
+		if rect.W < 1 || rect.H < 1 {
+			continue
+		}
+
+		child := children[i]
+		fmt.Fprintf(out, `<g><title>%s</title><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" stroke="#ffffff"/>`,
+			tooltip(child), rect.X, rect.Y, rect.W, rect.H, svgFill(child, depth, color))
+		if rect.H >= svgLabel {
+			if text := shorten(fmt.Sprintf("%s %s", child.Name, unit.NewFromBytes(child.Bytes()).RawSizeString()), rect.W-6); text != "" {
+				fmt.Fprintf(out, `<text x="%.1f" y="%.1f">%s</text>`, rect.X+3, rect.Y+12, html.EscapeString(text))
+			}
+		}
+		out.WriteString("</g>\n")
+
+		if child.ItemType == models.ItemTypeDirectory && rect.W > 2*svgLabel && rect.H > 2*svgLabel {
+			drawTreemap(out, child, opts, color, rect.Inset(2, svgLabel, 2, 2), depth+1)
+		}
+	}
+}
+
+// drawSunburst draws the root as a circle in the center of bounds and its descendants as rings around it.
+func drawSunburst(out *bufio.Writer, root *models.Item, opts Options, color SVGColor, bounds layout.Rect) {
+	cx, cy := bounds.X+bounds.W/2, bounds.Y+bounds.H/2
+	ring := math.Min(bounds.W, bounds.H) / 2 / float64(treeDepth(root, opts, 0)+1)
+
+	fmt.Fprintf(out, `<g><title>%s</title><circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="#ffffff"/>`,
+		tooltip(root), cx, cy, ring, svgFill(root, -1, color))
+	if text := shorten(unit.NewFromBytes(root.Bytes()).RawSizeString(), 2*ring); text != "" {
+		fmt.Fprintf(out, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="middle">%s</text>`, cx, cy, html.EscapeString(text))
+	}
+	out.WriteString("</g>\n")
+
+	var drawRing func(item *models.Item, start, end float64, depth int)
+	drawRing = func(item *models.Item, start, end float64, depth int) {
+		if item.Bytes() <= 0 {
+			return
+		}
+
+		inner, outer := ring*float64(depth+1), ring*float64(depth+2)
+		angle := start
+		for _, child := range opts.children(item, depth) {
+			span := (end - start) * float64(child.Bytes()) / float64(item.Bytes())
+			from, to := angle, angle+span
+			angle = to
+
+			// Arcs shorter than a pixel on their outer edge are not visible.
+			if span*outer < 1 {
+				continue
+			}
+
+			fmt.Fprintf(out, `<g><title>%s</title><path d="%s" fill="%s" stroke="#ffffff"/>`,
+				tooltip(child), arc(cx, cy, inner, outer, from, to), svgFill(child, depth, color))
+			if span*(inner+outer)/2 >= 12 {
+				writeRadialLabel(out, child.Name, cx, cy, inner, outer, (from+to)/2)
+			}
+			out.WriteString("</g>\n")
+
+			drawRing(child, from, to, depth+1)
+		}
+	}
+	drawRing(root, 0, 2*math.Pi, 0)
+}
+
+// writeRadialLabel writes text along the radius at angle (clockwise from the top), centered between inner and outer.
+func writeRadialLabel(out *bufio.Writer, text string, cx, cy, inner, outer, angle float64) {
+	text = shorten(text, outer-inner-4)
+	if text == "" {
+		return
+	}
+
+	radius := (inner + outer) / 2
+	x, y := cx+radius*math.Sin(angle), cy-radius*math.Cos(angle)
+	rotation := angle*180/math.Pi - 90
+	if angle > math.Pi {
+		// Labels on the left half are turned around, so they are not upside down.
+		rotation += 180
+	}
+
+	fmt.Fprintf(out, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="middle" transform="rotate(%.1f %.1f %.1f)">%s</text>`,
+		x, y, rotation, x, y, html.EscapeString(text))
+}
+
+// arc returns the path of the ring segment between the radii inner and outer and the angles from and to,
+// measured clockwise from the top.
+func arc(cx, cy, inner, outer, from, to float64) string {
+	// A path cannot describe a full circle with a single arc.
+	to = math.Min(to, from+2*math.Pi-1e-4)
+
+	large := 0
+	if to-from > math.Pi {
+		large = 1
+	}
+
+	point := func(radius, angle float64) (float64, float64) {
+		return cx + radius*math.Sin(angle), cy - radius*math.Cos(angle)
+	}
+	x0, y0 := point(outer, from)
+	x1, y1 := point(outer, to)
+	x2, y2 := point(inner, to)
+	x3, y3 := point(inner, from)
+
+	return fmt.Sprintf("M%.2f %.2f A%.2f %.2f 0 %d 1 %.2f %.2f L%.2f %.2f A%.2f %.2f 0 %d 0 %.2f %.2f Z",
+		x0, y0, outer, outer, large, x1, y1, x2, y2, inner, inner, large, x3, y3)
+}
+
+// treeDepth returns the number of levels below item (located at depth) that pass the options.
+func treeDepth(item *models.Item, opts Options, depth int) int {
+	deepest := 0
+	for _, child := range opts.children(item, depth) {
+		deepest = max(deepest, 1+treeDepth(child, opts, depth+1))
+	}
+
+	return deepest
+}
+
+// svgFill returns the fill color of item, located at depth below the root (-1 for the root itself).
+func svgFill(item *models.Item, depth int, color SVGColor) string {
+	if color == SVGColorDepth {
+		lightness := 72
+		if item.ItemType != models.ItemTypeDirectory {
+			lightness = 84
+		}
+		return fmt.Sprintf("hsl(%d, 55%%, %d%%)", ((depth+1)*47)%360, lightness)
+	}
+
+	if item.ItemType != models.ItemTypeDirectory {
+		return "hsl(30, 75%, 78%)"
+	}
+
+	return fmt.Sprintf("hsl(210, 45%%, %d%%)", max(82-(depth+1)*6, 40))
+}
+
+func tooltip(item *models.Item) string {
+	return html.EscapeString(fmt.Sprintf("%s\n%s", item.Path, unit.NewFromBytes(item.Bytes()).RawSizeString()))
+}
+
+// shorten cuts text to fit into width, marking cut text with an ellipsis.
+// It returns an empty string if not even two characters fit.
+func shorten(text string, width float64) string {
+	runes := []rune(text)
+	fits := int(width / svgCharWidth)
+	switch {
+	case len(runes) <= fits:
+		return text
+	case fits < 2:
+		return ""
+	default:
+		return string(runes[:fits-1]) + "…"
+	}
+}
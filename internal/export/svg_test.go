@@ -0,0 +1,104 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// svgElements parses the SVG image and counts its elements by name.
+func svgElements(t *testing.T, data []byte) map[string]int {
+	t.Helper()
+
+	elements := map[string]int{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "The image must be well-formed XML")
+
+		if start, ok := token.(xml.StartElement); ok {
+			elements[start.Name.Local]++
+		}
+	}
+
+	return elements
+}
+
+func TestSVG(t *testing.T) {
+	t.Parallel()
+
+	zero := 0
+
+	tests := []struct {
+		name     string
+		opts     Options
+		svg      SVGOptions
+		want     map[string]int
+		contains []string
+	}{
+		{
+			name:     "Treemap colored by type",
+			opts:     Options{},
+			svg:      SVGOptions{Chart: SVGChartTreemap, Color: SVGColorType, Width: 600, Height: 400},
+			want:     map[string]int{"svg": 1, "rect": 4, "path": 0, "circle": 0},
+			contains: []string{"hsl(30, 75%, 78%)", "root/dir/big\n"},
+		},
+		{
+			name:     "Treemap with depth limit",
+			opts:     Options{Depth: &zero},
+			svg:      SVGOptions{Chart: SVGChartTreemap, Color: SVGColorType, Width: 600, Height: 400},
+			want:     map[string]int{"svg": 1, "rect": 3},
+			contains: []string{">dir 1.95KB<"},
+		},
+		{
+			name:     "Sunburst colored by depth",
+			opts:     Options{},
+			svg:      SVGOptions{Chart: SVGChartSunburst, Color: SVGColorDepth, Width: 600, Height: 400},
+			want:     map[string]int{"svg": 1, "rect": 1, "path": 3, "circle": 1},
+			contains: []string{"hsl(47, 55%, 72%)", "hsl(94, 55%, 84%)", "rotate("},
+		},
+		{
+			name:     "Sunburst with threshold",
+			opts:     Options{Threshold: unit.NewFromBytes(1500)},
+			svg:      SVGOptions{Chart: SVGChartSunburst, Color: SVGColorType, Width: 600, Height: 400},
+			want:     map[string]int{"path": 2, "circle": 1},
+			contains: []string{"<title>root/dir/big\n"},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, SVG(&buf, testTree(), tt.opts, tt.svg))
+
+			elements := svgElements(t, buf.Bytes())
+			for name, count := range tt.want {
+				assert.Equal(t, count, elements[name], "Number of %s elements", name)
+			}
+			for _, text := range tt.contains {
+				assert.True(t, strings.Contains(buf.String(), text), "Image should contain %q", text)
+			}
+		})
+	}
+}
+
+func TestShorten(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "name", shorten("name", 28))
+	assert.Equal(t, "na…", shorten("name", 21))
+	assert.Equal(t, "", shorten("name", 13))
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// TableHeader is the header row written by CSV and TSV. Columns are only appended, never reordered.
+//
+//   - path: The path as scanned.
+//   - type: "directory" or "file".
+//   - depth: The depth below the root, which itself has depth 0.
+//   - size: The size in bytes, for directories the total of their content.
+//   - files: The number of files, 1 for a file and the total below for a directory.
+//   - mtime, ctime: The modification and change time (RFC 3339), empty if not captured by the scan.
+var TableHeader = []string{"path", "type", "depth", "size", "files", "mtime", "ctime"}
+
+// CSV writes the tree below root as comma-separated values, one row per item in pre-order,
+// so every directory is followed by its content. See TableHeader for the columns.
+//
+// Parameters:
+//   - w: The writer the table is written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the exported items. The root is always written.
+//
+// Returns:
+//   - error: An error if writing fails.
+func CSV(w io.Writer, root *models.Item, opts Options) error {
+	return table(w, root, opts, ',')
+}
+
+// TSV writes the tree below root like CSV, but with tab-separated values.
+//
+// Parameters:
+//   - w: The writer the table is written to.
+//   - root: The root item of a scanned tree.
+//   - opts: The options selecting the exported items. The root is always written.
+//
+// Returns:
+//   - error: An error if writing fails.
+func TSV(w io.Writer, root *models.Item, opts Options) error {
+	return table(w, root, opts, '\t')
+}
+
+func table(w io.Writer, root *models.Item, opts Options, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	if err := writer.Write(TableHeader); err != nil {
+		return err
+	}
+
+	files := map[*models.Item]int{}
+	countFiles(root, files)
+
+	var writeRows func(item *models.Item, depth int) error
+	writeRows = func(item *models.Item, depth int) error {
+		if err := writer.Write([]string{
+			item.Path,
+			item.ItemType.String(),
+			strconv.Itoa(depth),
+			strconv.FormatInt(item.Bytes(), 10),
+			strconv.Itoa(files[item]),
+			formatTime(item.ModTime),
+			formatTime(item.ChangeTime),
+		}); err != nil {
+			return err
+		}
+
+		for _, child := range opts.children(item, depth) {
+			if err := writeRows(child, depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := writeRows(root, 0); err != nil {
+		return err
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// countFiles records the number of files below (or being) item in files and returns it.
+func countFiles(item *models.Item, files map[*models.Item]int) int {
+	count := 0
+	if item.ItemType == models.ItemTypeFile {
+		count = 1
+	}
+
+	for _, child := range item.Children {
+		count += countFiles(child, files)
+	}
+	files[item] = count
+
+	return count
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
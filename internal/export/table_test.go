@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	depth := 0
+	header := "path,type,depth,size,files,mtime,ctime\n"
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "Full tree in pre-order",
+			opts: Options{},
+			want: header +
+				"root,directory,0,3000,2,2024-05-01T12:00:00Z,\n" +
+				"root/small,file,1,1000,1,,\n" +
+				"root/dir,directory,1,2000,1,,\n" +
+				"root/dir/big,file,2,2000,1,,\n",
+		},
+		{
+			name: "Directories only",
+			opts: Options{DirectoryOnly: true},
+			want: header +
+				"root,directory,0,3000,2,2024-05-01T12:00:00Z,\n" +
+				"root/dir,directory,1,2000,1,,\n",
+		},
+		{
+			name: "Depth limit and threshold",
+			opts: Options{Depth: &depth, Threshold: unit.NewFromBytes(1500)},
+			want: header +
+				"root,directory,0,3000,2,2024-05-01T12:00:00Z,\n" +
+				"root/dir,directory,1,2000,1,,\n",
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := testTree()
+			root.ModTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+			var buf bytes.Buffer
+			require.NoError(t, CSV(&buf, root, tt.opts))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestCSV_ScannedTree(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(base, "dir"), 0o755))
+	mtime := time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)
+	for _, name := range []string{"file", filepath.Join("dir", "nested")} {
+		require.NoError(t, os.WriteFile(filepath.Join(base, name), []byte("content"), 0o644))
+		require.NoError(t, os.Chtimes(filepath.Join(base, name), mtime, mtime))
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	root.Root = true
+	_, err := utils.Rescan(root, base, nil, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, CSV(&buf, root, Options{}))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	for _, row := range rows[1:] {
+		assert.NotEmpty(t, row[5], "mtime of %s", row[0])
+		assert.NotEmpty(t, row[6], "ctime of %s", row[0])
+
+		if row[1] == "file" {
+			got, err := time.Parse(time.RFC3339, row[5])
+			require.NoError(t, err)
+			assert.True(t, mtime.Equal(got), "mtime of %s is %s", row[0], row[5])
+		}
+	}
+}
+
+func TestTSV(t *testing.T) {
+	t.Parallel()
+
+	root := testTree()
+	root.Children[0].Path = "root/with\ttab"
+
+	var buf bytes.Buffer
+	require.NoError(t, TSV(&buf, root, Options{DirectoryOnly: true}))
+	assert.Equal(t, "path\ttype\tdepth\tsize\tfiles\tmtime\tctime\n"+
+		"root\tdirectory\t0\t3000\t2\t\t\n"+
+		"root/dir\tdirectory\t1\t2000\t1\t\t\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, TSV(&buf, root, Options{Threshold: unit.NewFromBytes(1000), Depth: new(int)}))
+	assert.Contains(t, buf.String(), "\"root/with\ttab\"\tfile\t1\t1000\t1\t\t\n")
+}
@@ -0,0 +1,158 @@
+package filter
+
+import (
+	"sort"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+// Options selects the items of a tree that match.
+//
+// Fields:
+//   - DirectoryOnly: Only directories match.
+//   - Depth: The maximum depth of items (0 for the children of the root). Deeper items are neither
+//     shown nor counted as hidden. If nil, no depth limit is applied.
+//   - Threshold: The minimum size of matching items. If nil, no size threshold is applied.
+type Options struct {
+	DirectoryOnly bool
+	Depth         *int
+	Threshold     *unit.Size
+}
+
+// Hidden summarizes the children of a directory that were filtered out.
+//
+// Fields:
+//   - Count: The number of hidden children, a hidden directory counts as one.
+//   - Size: The total size of the hidden children in bytes.
+type Hidden struct {
+	Count int
+	Size  int64
+}
+
+// Result holds which items of a tree survive a filter: all matching items plus their ancestors,
+// so the surviving items always form a connected tree below the root.
+type Result struct {
+	visible map[*models.Item]bool
+	hidden  map[*models.Item]Hidden
+}
+
+// Apply filters the tree below root. The root itself always survives.
+//
+// Parameters:
+//   - root: The root item of the tree.
+//   - opts: The options that select matching items.
+//
+// Returns:
+//   - *Result: The surviving items and the hidden children per directory.
+func Apply(root *models.Item, opts Options) *Result {
+	result := &Result{visible: map[*models.Item]bool{}, hidden: map[*models.Item]Hidden{}}
+	result.visit(root, -1, opts)
+
+	return result
+}
+
+// visit decides whether item at the given depth survives, after visiting its children within the depth limit.
+func (r *Result) visit(item *models.Item, depth int, opts Options) bool {
+	survives := matches(item, opts)
+	var hidden Hidden
+	if opts.Depth == nil || depth+1 <= *opts.Depth {
+		for _, child := range item.Children {
+			if r.visit(child, depth+1, opts) {
+				survives = true
+				continue
+			}
+
+			hidden.Count++
+			hidden.Size += child.Bytes()
+		}
+	}
+
+	if survives || depth < 0 {
+		r.visible[item] = true
+		if hidden.Count > 0 {
+			r.hidden[item] = hidden
+		}
+	}
+
+	return survives
+}
+
+// Visible reports whether item survived the filter.
+func (r *Result) Visible(item *models.Item) bool {
+	return r.visible[item]
+}
+
+// Children returns the surviving items of children, e.g. the sorted children of a directory, keeping their order.
+func (r *Result) Children(children []*models.Item) []*models.Item {
+	visible := make([]*models.Item, 0, len(children))
+	for _, child := range children {
+		if r.visible[child] {
+			visible = append(visible, child)
+		}
+	}
+
+	return visible
+}
+
+// Hidden returns the children of the surviving item that were filtered out, zero if none were.
+func (r *Result) Hidden(item *models.Item) Hidden {
+	return r.hidden[item]
+}
+
+// Collapse selects the children of a directory that are shown individually: the maxChildren largest ones
+// that take at least minShare of the directory. The others are collapsed into one summary, unless that would
+// only hide a single child, which is then shown instead. The summary also covers the children hidden by a
+// filter, so the shown children and the summary add up to the directory.
+//
+// Parameters:
+//   - children: The surviving children of a directory in the order they are printed.
+//   - filtered: The children of the directory hidden by a filter, see Result.Hidden.
+//   - parentSize: The size of the directory in bytes.
+//   - maxChildren: The maximum number of children shown individually, 0 for no limit.
+//   - minShare: The minimum share of the directory size (0 to 1) of children shown individually, 0 for no limit.
+//
+// Returns:
+//   - []*models.Item: The children shown individually, in the given order.
+//   - Hidden: The number and total size of the collapsed and filtered children, zero if nothing was collapsed.
+func Collapse(children []*models.Item, filtered Hidden, parentSize int64, maxChildren int, minShare float64) ([]*models.Item, Hidden) {
+	ranked := append([]*models.Item(nil), children...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Bytes() > ranked[j].Bytes() })
+
+	shown := map[*models.Item]bool{}
+	for i, child := range ranked {
+		if maxChildren > 0 && i >= maxChildren {
+			break
+		}
+		if minShare > 0 && (parentSize <= 0 || float64(child.Bytes())/float64(parentSize) < minShare) {
+			break
+		}
+		shown[child] = true
+	}
+
+	if len(children)-len(shown) < 2 {
+		return children, Hidden{}
+	}
+
+	kept := make([]*models.Item, 0, len(shown))
+	others := filtered
+	for _, child := range children {
+		if shown[child] {
+			kept = append(kept, child)
+			continue
+		}
+
+		others.Count++
+		others.Size += child.Bytes()
+	}
+
+	return kept, others
+}
+
+func matches(item *models.Item, opts Options) bool {
+	if opts.DirectoryOnly && item.ItemType != models.ItemTypeDirectory {
+		return false
+	}
+
+	return opts.Threshold == nil || opts.Threshold.Size <= item.Bytes()
+}
@@ -0,0 +1,195 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTree builds root (3000) with the file small (1000) and the directory dir (2000),
+// which contains the file big (1500), the file tiny (100) and the directory sub (400)
+// containing the file leaf (400).
+func testTree() *models.Item {
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(3000))
+	root.Root = true
+	sub := models.NewItemWithSize("sub", "root/dir/sub", models.ItemTypeDirectory, unit.NewFromBytes(400))
+	sub.Children = append(sub.Children, models.NewItemWithSize("leaf", "root/dir/sub/leaf", models.ItemTypeFile, unit.NewFromBytes(400)))
+	dir := models.NewItemWithSize("dir", "root/dir", models.ItemTypeDirectory, unit.NewFromBytes(2000))
+	dir.Children = append(dir.Children,
+		models.NewItemWithSize("big", "root/dir/big", models.ItemTypeFile, unit.NewFromBytes(1500)),
+		models.NewItemWithSize("tiny", "root/dir/tiny", models.ItemTypeFile, unit.NewFromBytes(100)),
+		sub)
+	root.Children = append(root.Children, models.NewItemWithSize("small", "root/small", models.ItemTypeFile, unit.NewFromBytes(1000)), dir)
+
+	return root
+}
+
+// visible returns the names of the surviving items in pre-order.
+func visible(result *Result, item *models.Item) []string {
+	names := []string{}
+	for _, child := range result.Children(item.Children) {
+		names = append(names, child.Name)
+		names = append(names, visible(result, child)...)
+	}
+	return names
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		opts   Options
+		want   []string
+		hidden map[string]Hidden
+	}{
+		{
+			name:   "No filter",
+			opts:   Options{},
+			want:   []string{"small", "dir", "big", "tiny", "sub", "leaf"},
+			hidden: map[string]Hidden{},
+		},
+		{
+			name:   "Threshold",
+			opts:   Options{Threshold: unit.NewFromBytes(1000)},
+			want:   []string{"small", "dir", "big"},
+			hidden: map[string]Hidden{"dir": {Count: 2, Size: 500}},
+		},
+		{
+			name:   "Directories only",
+			opts:   Options{DirectoryOnly: true},
+			want:   []string{"dir", "sub"},
+			hidden: map[string]Hidden{"root": {Count: 1, Size: 1000}, "dir": {Count: 2, Size: 1600}, "sub": {Count: 1, Size: 400}},
+		},
+		{
+			name:   "Depth limit does not count as hidden",
+			opts:   Options{Depth: intPtr(0)},
+			want:   []string{"small", "dir"},
+			hidden: map[string]Hidden{},
+		},
+		{
+			name:   "Depth and threshold",
+			opts:   Options{Depth: intPtr(1), Threshold: unit.NewFromBytes(200)},
+			want:   []string{"small", "dir", "big", "sub"},
+			hidden: map[string]Hidden{"dir": {Count: 1, Size: 100}},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := testTree()
+			result := Apply(root, tt.opts)
+
+			assert.True(t, result.Visible(root), "The root always survives")
+			assert.Equal(t, tt.want, visible(result, root))
+
+			hidden := map[string]Hidden{}
+			var collect func(item *models.Item)
+			collect = func(item *models.Item) {
+				if h := result.Hidden(item); h.Count > 0 {
+					hidden[item.Name] = h
+				}
+				for _, child := range item.Children {
+					collect(child)
+				}
+			}
+			collect(root)
+			assert.Equal(t, tt.hidden, hidden)
+		})
+	}
+}
+
+func TestApply_KeepsAncestors(t *testing.T) {
+	t.Parallel()
+
+	// A directory smaller than the threshold survives if a descendant matches,
+	// e.g. for sizes of an imported dump that do not add up.
+	root := testTree()
+	sub := root.Children[1].Children[2]
+	sub.Children[0].Size = unit.NewFromBytes(5000)
+
+	result := Apply(root, Options{Threshold: unit.NewFromBytes(1800)})
+	assert.Equal(t, []string{"dir", "sub", "leaf"}, visible(result, root))
+	assert.Equal(t, Hidden{Count: 2, Size: 1600}, result.Hidden(root.Children[1]))
+	assert.Equal(t, Hidden{Count: 1, Size: 1000}, result.Hidden(root))
+}
+
+func TestCollapse(t *testing.T) {
+	t.Parallel()
+
+	// Sizes add up to 1000.
+	sizes := map[string]int64{"a": 50, "b": 400, "c": 10, "d": 300, "e": 240}
+	children := []*models.Item{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		children = append(children, models.NewItemWithSize(name, name, models.ItemTypeFile, unit.NewFromBytes(sizes[name])))
+	}
+
+	tests := []struct {
+		name        string
+		maxChildren int
+		minShare    float64
+		filtered    Hidden
+		want        []string
+		others      Hidden
+	}{
+		{name: "No limit", want: []string{"a", "b", "c", "d", "e"}},
+		{name: "Largest children in the given order", maxChildren: 2, want: []string{"b", "d"}, others: Hidden{Count: 3, Size: 300}},
+		{name: "Share cutoff", minShare: 0.2, want: []string{"b", "d", "e"}, others: Hidden{Count: 2, Size: 60}},
+		{name: "Both limits", maxChildren: 1, minShare: 0.2, want: []string{"b"}, others: Hidden{Count: 4, Size: 600}},
+		{name: "A single child is not collapsed", maxChildren: 4, want: []string{"a", "b", "c", "d", "e"}},
+		{name: "Limit above the number of children", maxChildren: 10, want: []string{"a", "b", "c", "d", "e"}},
+		{name: "Filtered children join the others", maxChildren: 2, filtered: Hidden{Count: 2, Size: 70}, want: []string{"b", "d"}, others: Hidden{Count: 5, Size: 370}},
+		{name: "Filtered children alone are not collapsed", filtered: Hidden{Count: 2, Size: 70}, want: []string{"a", "b", "c", "d", "e"}},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kept, others := Collapse(children, tt.filtered, 1000, tt.maxChildren, tt.minShare)
+
+			names := []string{}
+			for _, child := range kept {
+				names = append(names, child.Name)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, tt.others, others)
+		})
+	}
+}
+
+func TestCollapse_Filtered(t *testing.T) {
+	t.Parallel()
+
+	root := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(1000), Root: true, Children: []*models.Item{
+		{Name: "a", Path: "root/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(400)},
+		{Name: "b", Path: "root/b", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(300)},
+		{Name: "c", Path: "root/c", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(150)},
+		{Name: "d", Path: "root/d", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(100)},
+		{Name: "e", Path: "root/e", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(30)},
+		{Name: "f", Path: "root/f", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(20)},
+	}}
+
+	result := Apply(root, Options{Threshold: unit.NewFromBytes(100)})
+	kept, others := Collapse(result.Children(root.Children), result.Hidden(root), 1000, 2, 0)
+
+	assert.Equal(t, []*models.Item{root.Children[0], root.Children[1]}, kept)
+	assert.Equal(t, Hidden{Count: 4, Size: 300}, others, "Expected the filtered children to be counted as others")
+
+	shown := others.Size
+	for _, child := range kept {
+		shown += child.Size.Size
+	}
+	assert.Equal(t, root.Size.Size, shown, "Expected the shown rows to add up to the directory")
+}
@@ -0,0 +1,66 @@
+package growth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/diff"
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Rate describes how fast an item grew between two scans.
+//
+// Fields:
+//   - Path: The path of the item.
+//   - ItemType: The type of the item (e.g., file, directory).
+//   - Size: The size in bytes at the second scan.
+//   - Delta: The number of bytes the item grew by.
+//   - BytesPerSecond: The growth rate over the time between both scans.
+type Rate struct {
+	Path           string
+	ItemType       models.ItemType
+	Size           int64
+	Delta          int64
+	BytesPerSecond float64
+}
+
+// Rates compares two scans of the same tree taken elapsed apart and returns every
+// item below the root that grew, ranked by growth rate (fastest first).
+//
+// Parameters:
+//   - before: The root item of the earlier scan.
+//   - after: The root item of the later scan.
+//   - elapsed: The time between both scans.
+//
+// Returns:
+//
+//	The growing items sorted by BytesPerSecond in descending order, ties by path.
+func Rates(before, after *models.Item, elapsed time.Duration) []Rate {
+	rates := []Rate{}
+	collect(diff.Compare(before, after), elapsed.Seconds(), &rates)
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Delta != rates[j].Delta {
+			return rates[i].Delta > rates[j].Delta
+		}
+
+		return rates[i].Path < rates[j].Path
+	})
+
+	return rates
+}
+
+func collect(node *diff.Node, seconds float64, rates *[]Rate) {
+	for _, child := range node.Children {
+		if child.Delta() > 0 {
+			rate := Rate{Path: child.Path, ItemType: child.ItemType, Size: child.NewSize, Delta: child.Delta()}
+			if seconds > 0 {
+				rate.BytesPerSecond = float64(rate.Delta) / seconds
+			}
+			*rates = append(*rates, rate)
+		}
+
+		// A directory may shrink while items below it grow, e.g. when a log is rotated.
+		collect(child, seconds, rates)
+	}
+}
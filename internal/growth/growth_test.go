@@ -0,0 +1,74 @@
+package growth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRates(t *testing.T) {
+	t.Parallel()
+
+	before := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(30), Children: []*models.Item{
+		{Name: "logs", Path: "root/logs", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(20), Children: []*models.Item{
+			{Name: "app.log", Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+			{Name: "db.log", Path: "root/logs/db.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+		}},
+		{Name: "tmp", Path: "root/tmp", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+	}}
+	after := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(60), Children: []*models.Item{
+		{Name: "logs", Path: "root/logs", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(60), Children: []*models.Item{
+			{Name: "app.log", Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(30)},
+			{Name: "db.log", Path: "root/logs/db.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(20)},
+			{Name: "new.log", Path: "root/logs/new.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+		}},
+	}}
+
+	expected := []Rate{
+		{Path: "root/logs", ItemType: models.ItemTypeDirectory, Size: 60, Delta: 40, BytesPerSecond: 4},
+		{Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: 30, Delta: 20, BytesPerSecond: 2},
+		{Path: "root/logs/db.log", ItemType: models.ItemTypeFile, Size: 20, Delta: 10, BytesPerSecond: 1},
+		{Path: "root/logs/new.log", ItemType: models.ItemTypeFile, Size: 10, Delta: 10, BytesPerSecond: 1},
+	}
+
+	assert.Equal(t, expected, Rates(before, after, 10*time.Second), "Rates do not match")
+}
+
+func TestRates_NoGrowth(t *testing.T) {
+	t.Parallel()
+
+	before := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(10), Children: []*models.Item{
+		{Name: "a", Path: "root/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+	}}
+	after := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(5), Children: []*models.Item{
+		{Name: "a", Path: "root/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(5)},
+	}}
+
+	assert.Empty(t, Rates(before, after, time.Second), "Expected no growing items")
+}
+
+func TestRates_GrowthBelowShrinkingDirectory(t *testing.T) {
+	t.Parallel()
+
+	before := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(110), Children: []*models.Item{
+		{Name: "logs", Path: "root/logs", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(110), Children: []*models.Item{
+			{Name: "app.log", Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(10)},
+			{Name: "app.log.1", Path: "root/logs/app.log.1", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(100)},
+		}},
+	}}
+	after := &models.Item{Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(30), Children: []*models.Item{
+		{Name: "logs", Path: "root/logs", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(30), Children: []*models.Item{
+			{Name: "app.log", Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(30)},
+		}},
+	}}
+
+	expected := []Rate{
+		{Path: "root/logs/app.log", ItemType: models.ItemTypeFile, Size: 30, Delta: 20, BytesPerSecond: 2},
+	}
+
+	assert.Equal(t, expected, Rates(before, after, 10*time.Second), "Rates do not match")
+}
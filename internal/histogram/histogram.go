@@ -0,0 +1,84 @@
+package histogram
+
+import "github.com/StevenCyb/MemSpace/internal/models"
+
+// Bucket counts the files whose size falls into a range.
+//
+// Fields:
+//   - Label: A short description of the range, e.g. "<4KB".
+//   - Upper: The exclusive upper bound of the range in bytes, -1 if unbounded.
+//   - Files: The number of files in the range.
+//   - Bytes: The total size of the files in the range.
+type Bucket struct {
+	Label string
+	Upper int64
+	Files int64
+	Bytes int64
+}
+
+// Histogram is a distribution of file sizes over logarithmic buckets.
+//
+// Fields:
+//   - Path: The path of the directory the histogram was collected for.
+//   - Buckets: The buckets, from the empty files to the largest ones.
+//   - Files: The total number of files.
+//   - Bytes: The total size of all files.
+type Histogram struct {
+	Path    string
+	Buckets []Bucket
+	Files   int64
+	Bytes   int64
+}
+
+// bounds are the labels and exclusive upper bounds of the buckets, growing by a factor of 4.
+var bounds = []struct {
+	label string
+	upper int64
+}{
+	{"0", 1}, {"<1KB", 1 << 10}, {"<4KB", 4 << 10}, {"<16KB", 16 << 10}, {"<64KB", 64 << 10},
+	{"<256KB", 256 << 10}, {"<1MB", 1 << 20}, {"<4MB", 4 << 20}, {"<16MB", 16 << 20}, {"<64MB", 64 << 20},
+	{"<256MB", 256 << 20}, {"<1GB", 1 << 30}, {"<4GB", 4 << 30}, {"<16GB", 16 << 30}, {"<100GB", 100 << 30},
+	{">=100GB", -1},
+}
+
+// New creates an empty histogram with the buckets 0, <1KB, <4KB, ..., <16GB, <100GB and >=100GB.
+func New(path string) *Histogram {
+	h := &Histogram{Path: path, Buckets: make([]Bucket, 0, len(bounds))}
+	for _, bound := range bounds {
+		h.Buckets = append(h.Buckets, Bucket{Label: bound.label, Upper: bound.upper})
+	}
+
+	return h
+}
+
+// Add counts a file of the given size.
+func (h *Histogram) Add(size int64) {
+	h.Files++
+	h.Bytes += size
+
+	for i := range h.Buckets {
+		if h.Buckets[i].Upper < 0 || size < h.Buckets[i].Upper {
+			h.Buckets[i].Files++
+			h.Buckets[i].Bytes += size
+			return
+		}
+	}
+}
+
+// FromTree creates a histogram of all files below item.
+func FromTree(item *models.Item) *Histogram {
+	h := New(item.Path)
+	h.addTree(item)
+
+	return h
+}
+
+func (h *Histogram) addTree(item *models.Item) {
+	for _, child := range item.Children {
+		if child.ItemType == models.ItemTypeDirectory {
+			h.addTree(child)
+		} else if child.Size != nil {
+			h.Add(child.Size.Size)
+		}
+	}
+}
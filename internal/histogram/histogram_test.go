@@ -0,0 +1,67 @@
+package histogram
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram_Add(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size  int64
+		label string
+	}{
+		{size: 0, label: "0"},
+		{size: 1, label: "<1KB"},
+		{size: 1023, label: "<1KB"},
+		{size: 1024, label: "<4KB"},
+		{size: 5 << 20, label: "<16MB"},
+		{size: 100<<30 - 1, label: "<100GB"},
+		{size: 100 << 30, label: ">=100GB"},
+		{size: 1 << 50, label: ">=100GB"},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.label, func(t *testing.T) {
+			t.Parallel()
+
+			h := New("")
+			h.Add(tt.size)
+
+			for _, bucket := range h.Buckets {
+				if bucket.Label == tt.label {
+					assert.Equal(t, int64(1), bucket.Files, "Expected the file in bucket %s", bucket.Label)
+					assert.Equal(t, tt.size, bucket.Bytes)
+				} else {
+					assert.Zero(t, bucket.Files, "Expected no file in bucket %s", bucket.Label)
+				}
+			}
+		})
+	}
+}
+
+func TestFromTree(t *testing.T) {
+	t.Parallel()
+
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(2050))
+	dir := models.NewItemWithSize("dir", "root/dir", models.ItemTypeDirectory, unit.NewFromBytes(2048))
+	dir.Children = append(dir.Children, models.NewItemWithSize("b", "root/dir/b", models.ItemTypeFile, unit.NewFromBytes(2048)))
+	root.Children = append(root.Children,
+		models.NewItemWithSize("a", "root/a", models.ItemTypeFile, unit.NewFromBytes(2)),
+		models.NewItemWithSize("e", "root/e", models.ItemTypeFile, unit.NewFromBytes(0)),
+		dir)
+
+	h := FromTree(root)
+	assert.Equal(t, "root", h.Path)
+	assert.Equal(t, int64(3), h.Files)
+	assert.Equal(t, int64(2050), h.Bytes)
+	assert.Equal(t, Bucket{Label: "0", Upper: 1, Files: 1}, h.Buckets[0])
+	assert.Equal(t, Bucket{Label: "<1KB", Upper: 1 << 10, Files: 1, Bytes: 2}, h.Buckets[1])
+	assert.Equal(t, Bucket{Label: "<4KB", Upper: 4 << 10, Files: 1, Bytes: 2048}, h.Buckets[2])
+}
@@ -0,0 +1,115 @@
+package layout
+
+import "sort"
+
+// Rect is an axis-aligned rectangle.
+//
+// Fields:
+//   - X, Y: The position of the top-left corner.
+//   - W, H: The width and height.
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Area returns the area of the rectangle.
+func (r Rect) Area() float64 {
+	return r.W * r.H
+}
+
+// Inset returns the rectangle shrunk by left, top, right and bottom. Width and height never become negative.
+func (r Rect) Inset(left, top, right, bottom float64) Rect {
+	inset := Rect{X: r.X + left, Y: r.Y + top, W: r.W - left - right, H: r.H - top - bottom}
+	inset.W, inset.H = max(inset.W, 0), max(inset.H, 0)
+
+	return inset
+}
+
+// Squarify lays out rectangles with the given weights in bounds using the squarified treemap algorithm
+// of Bruls, Huizing and van Wijk, which keeps the aspect ratios close to 1. The area of a rectangle is
+// its share of total, so if the weights sum up to less than total, part of bounds stays empty.
+//
+// Parameters:
+//   - weights: The weights (e.g., sizes) of the rectangles. Weights <= 0 get an empty rectangle.
+//   - total: The weight covering all of bounds, at least the sum of the weights.
+//   - bounds: The rectangle to lay out in.
+//
+// Returns:
+//   - []Rect: One rectangle per weight, in the order of the weights.
+func Squarify(weights []float64, total float64, bounds Rect) []Rect {
+	rects := make([]Rect, len(weights))
+	if total <= 0 || bounds.Area() <= 0 {
+		return rects
+	}
+
+	// The algorithm places the largest rectangles first.
+	order := make([]int, 0, len(weights))
+	for i, weight := range weights {
+		if weight > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return weights[order[a]] > weights[order[b]] })
+
+	scale := bounds.Area() / total
+	free := bounds
+	var row []int
+	rowArea := func() float64 {
+		sum := 0.0
+		for _, i := range row {
+			sum += weights[i] * scale
+		}
+		return sum
+	}
+
+	place := func() {
+		sum := rowArea()
+		if free.W >= free.H {
+			width := min(sum/free.H, free.W)
+			offset := free.Y
+			for _, i := range row {
+				height := weights[i] * scale / width
+				rects[i] = Rect{X: free.X, Y: offset, W: width, H: height}
+				offset += height
+			}
+			free.X, free.W = free.X+width, free.W-width
+		} else {
+			height := min(sum/free.W, free.H)
+			offset := free.X
+			for _, i := range row {
+				width := weights[i] * scale / height
+				rects[i] = Rect{X: offset, Y: free.Y, W: width, H: height}
+				offset += width
+			}
+			free.Y, free.H = free.Y+height, free.H-height
+		}
+		row = row[:0]
+	}
+
+	for _, i := range order {
+		side := min(free.W, free.H)
+		if len(row) > 0 && worst(append(row, i), weights, scale, side) > worst(row, weights, scale, side) {
+			place()
+		}
+		row = append(row, i)
+	}
+	if len(row) > 0 {
+		place()
+	}
+
+	return rects
+}
+
+// worst returns the highest aspect ratio of the rectangles of a row laid out along a side of the given length.
+func worst(row []int, weights []float64, scale, side float64) float64 {
+	sum, highest, lowest := 0.0, 0.0, -1.0
+	for _, i := range row {
+		area := weights[i] * scale
+		sum += area
+		highest = max(highest, area)
+		if lowest < 0 || area < lowest {
+			lowest = area
+		}
+	}
+
+	return max(side*side*highest/(sum*sum), sum*sum/(side*side*lowest))
+}
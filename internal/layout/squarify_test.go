@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquarify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		weights []float64
+		total   float64
+		bounds  Rect
+		want    []Rect
+	}{
+		{
+			name:    "Example of the paper",
+			weights: []float64{6, 6, 4, 3, 2, 2, 1},
+			total:   24,
+			bounds:  Rect{W: 6, H: 4},
+			want: []Rect{
+				{X: 0, Y: 0, W: 3, H: 2},
+				{X: 0, Y: 2, W: 3, H: 2},
+				{X: 3, Y: 0, W: 12.0 / 7, H: 7.0 / 3},
+				{X: 3 + 12.0/7, Y: 0, W: 9.0 / 7, H: 7.0 / 3},
+				{X: 3, Y: 7.0 / 3, W: 1.2, H: 5.0 / 3},
+				{X: 4.2, Y: 7.0 / 3, W: 1.2, H: 5.0 / 3},
+				{X: 5.4, Y: 7.0 / 3, W: 0.6, H: 5.0 / 3},
+			},
+		},
+		{
+			name:    "Unsorted weights keep their order",
+			weights: []float64{1, 3},
+			total:   4,
+			bounds:  Rect{W: 4, H: 1},
+			want:    []Rect{{X: 3, Y: 0, W: 1, H: 1}, {X: 0, Y: 0, W: 3, H: 1}},
+		},
+		{
+			name:    "Remaining weight stays empty",
+			weights: []float64{2, 0},
+			total:   4,
+			bounds:  Rect{X: 10, Y: 10, W: 4, H: 2},
+			want:    []Rect{{X: 10, Y: 10, W: 2, H: 2}, {}},
+		},
+		{
+			name:    "Empty bounds",
+			weights: []float64{1},
+			total:   1,
+			bounds:  Rect{W: 0, H: 5},
+			want:    []Rect{{}},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Squarify(tt.weights, tt.total, tt.bounds)
+			assert.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.InDelta(t, tt.want[i].X, got[i].X, 1e-9, "X of %d", i)
+				assert.InDelta(t, tt.want[i].Y, got[i].Y, 1e-9, "Y of %d", i)
+				assert.InDelta(t, tt.want[i].W, got[i].W, 1e-9, "W of %d", i)
+				assert.InDelta(t, tt.want[i].H, got[i].H, 1e-9, "H of %d", i)
+			}
+		})
+	}
+}
+
+func TestRect_Inset(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Rect{X: 1, Y: 2, W: 6, H: 5}, Rect{W: 8, H: 8}.Inset(1, 2, 1, 1))
+	assert.Equal(t, Rect{X: 5, Y: 5, W: 0, H: 0}, Rect{W: 8, H: 8}.Inset(5, 5, 5, 5))
+}
@@ -1,6 +1,12 @@
 package models
 
-import "github.com/StevenCyb/MemSpace/internal/unit"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
 
 // ItemType represents a custom type used to define different categories or types of items.
 // It is implemented as a byte to minimize memory usage and improve performance.
@@ -12,6 +18,15 @@ const (
 	ItemTypeFile
 )
 
+// String returns a lowercase, human-readable name for the ItemType ("directory" or "file").
+func (t ItemType) String() string {
+	if t == ItemTypeDirectory {
+		return "directory"
+	}
+
+	return "file"
+}
+
 // Item represents a hierarchical structure that can be used to model
 // files, directories, or other similar entities. Each Item can have
 // child Items, forming a tree-like structure.
@@ -22,15 +37,19 @@ const (
 //   - Path: The full path to the Item.
 //   - ItemType: The type of the Item (e.g., file, directory).
 //   - Size: The size of the Item, represented as a pointer to a unit.Size.
+//   - ModTime: The modification time of the Item, zero if unknown.
+//   - ChangeTime: The inode change time (ctime) of the Item, zero if unknown.
 //   - Children: A slice of child Items, representing the hierarchical
 //     relationship.
 type Item struct {
-	Root     bool
-	Name     string
-	Path     string
-	ItemType ItemType
-	Size     *unit.Size
-	Children []*Item
+	Root       bool
+	Name       string
+	Path       string
+	ItemType   ItemType
+	Size       *unit.Size
+	ModTime    time.Time
+	ChangeTime time.Time
+	Children   []*Item
 }
 
 // NewItem creates and returns a new Item instance with the specified name, path, and item type.
@@ -74,3 +93,114 @@ func NewItemWithSize(name, path string, itemType ItemType, size *unit.Size) *Ite
 		Children: make([]*Item, 0),
 	}
 }
+
+// Find returns the chain of items from i down to the item with the given path,
+// following the children whose path is a prefix of the given one.
+//
+// Parameters:
+//   - path: The path of the item to look up, as stored in the Path field.
+//
+// Returns:
+//
+//	A slice starting with i and ending with the item with the given path,
+//	or nil if no such item exists in the tree.
+func (i *Item) Find(path string) []*Item {
+	lineage := []*Item{i}
+	for current := i; current.Path != path; {
+		var next *Item
+		for _, child := range current.Children {
+			if child.Path == path || strings.HasPrefix(path, child.Path+string(filepath.Separator)) {
+				next = child
+				break
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+
+		lineage = append(lineage, next)
+		current = next
+	}
+
+	return lineage
+}
+
+// Detach removes the item with the given path from the tree below i and subtracts its
+// size from all its ancestors, so the sizes stay consistent up to i.
+//
+// Parameters:
+//   - path: The path of the item to remove, as stored in the Path field.
+//
+// Returns:
+//
+//	The removed item, or nil if no such item exists below i.
+func (i *Item) Detach(path string) *Item {
+	lineage := i.Find(path)
+	if len(lineage) < 2 {
+		return nil
+	}
+
+	item, parent := lineage[len(lineage)-1], lineage[len(lineage)-2]
+	for index, child := range parent.Children {
+		if child == item {
+			parent.Children = append(parent.Children[:index], parent.Children[index+1:]...)
+			break
+		}
+	}
+
+	if item.Size != nil {
+		for _, ancestor := range lineage[:len(lineage)-1] {
+			if ancestor.Size != nil {
+				ancestor.Size.Add(&unit.Size{Size: -item.Size.Size})
+			}
+		}
+	}
+
+	return item
+}
+
+// Bytes returns the size of the Item in bytes, or 0 if its size is unknown.
+//
+// Returns:
+//
+//	The size in bytes.
+func (i *Item) Bytes() int64 {
+	if i.Size == nil {
+		return 0
+	}
+
+	return i.Size.Size
+}
+
+// FileCounts caches the number of files below (or being) items, so directories are counted
+// only once when they are compared repeatedly, e.g. while sorting. The zero value is ready to use.
+type FileCounts map[*Item]int
+
+// Of returns the number of files below item, counting item itself if it is a file.
+//
+// Parameters:
+//   - item: The item to count the files of.
+//
+// Returns:
+//
+//	The number of files.
+func (c *FileCounts) Of(item *Item) int {
+	if *c == nil {
+		*c = FileCounts{}
+	}
+	if count, ok := (*c)[item]; ok {
+		return count
+	}
+
+	count := 0
+	if item.ItemType != ItemTypeDirectory {
+		count = 1
+	}
+	for _, child := range item.Children {
+		count += c.Of(child)
+	}
+	(*c)[item] = count
+
+	return count
+}
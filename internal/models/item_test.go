@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem_Bytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		item     *Item
+		expected int64
+	}{
+		{name: "Known size", item: NewItemWithSize("a", "a", ItemTypeFile, unit.NewFromBytes(42)), expected: 42},
+		{name: "Unknown size", item: NewItem("a", "a", ItemTypeFile), expected: 0},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.item.Bytes())
+		})
+	}
+}
+
+func TestFileCounts_Of(t *testing.T) {
+	t.Parallel()
+
+	root := &Item{Name: "root", Path: "root", ItemType: ItemTypeDirectory, Children: []*Item{
+		{Name: "a", Path: "root/a", ItemType: ItemTypeFile},
+		{Name: "empty", Path: "root/empty", ItemType: ItemTypeDirectory},
+		{Name: "sub", Path: "root/sub", ItemType: ItemTypeDirectory, Children: []*Item{
+			{Name: "b", Path: "root/sub/b", ItemType: ItemTypeFile},
+			{Name: "c", Path: "root/sub/c", ItemType: ItemTypeFile},
+		}},
+	}}
+
+	var counts FileCounts
+	assert.Equal(t, 3, counts.Of(root))
+	assert.Equal(t, 2, counts.Of(root.Children[2]))
+	assert.Equal(t, 0, counts.Of(root.Children[1]))
+	assert.Equal(t, 1, counts.Of(root.Children[0]))
+
+	// Counts are cached, so changes to the tree are only seen by a new FileCounts.
+	root.Children[2].Children = root.Children[2].Children[:1]
+	assert.Equal(t, 2, counts.Of(root.Children[2]))
+	assert.Equal(t, 1, (&FileCounts{}).Of(root.Children[2]))
+}
@@ -0,0 +1,302 @@
+package ncdu
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+const (
+	// MajorVersion is the major version of the ncdu JSON dump format. Dumps with another major version are rejected.
+	MajorVersion = 1
+	// MinorVersion is the minor version of the ncdu JSON dump format written by Write.
+	MinorVersion = 2
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported ncdu dump version")
+
+// metadata is the header object following the version numbers of a dump.
+type metadata struct {
+	ProgName  string `json:"progname"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// info describes a file or directory of a dump. Only the fields used by MemSpace are read and written.
+//
+// Fields:
+//   - Name: The name of the entry, the scanned path for the root directory.
+//   - ASize: The apparent size in bytes.
+//   - DSize: The disk usage in bytes.
+//   - MTime: The modification time in Unix seconds, 0 if unknown.
+//   - Excluded: The reason why ncdu did not scan the entry, empty if it was scanned.
+type info struct {
+	Name     string `json:"name"`
+	ASize    int64  `json:"asize,omitempty"`
+	DSize    int64  `json:"dsize,omitempty"`
+	MTime    int64  `json:"mtime,omitempty"`
+	Excluded string `json:"excluded,omitempty"`
+}
+
+// Write writes the tree below root in the JSON dump format of ncdu, so it can be browsed with `ncdu -f`.
+// The whole tree is written, as ncdu calculates the directory sizes itself. MemSpace only knows the
+// apparent size of files, which is therefore also written as their disk usage.
+//
+// Parameters:
+//   - w: The writer the dump is written to.
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - error: An error if writing fails.
+func Write(w io.Writer, root *models.Item) error {
+	out := bufio.NewWriter(w)
+
+	header, err := json.Marshal(metadata{ProgName: "MemSpace", Timestamp: time.Now().Unix()})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "[%d,%d,%s,\n", MajorVersion, MinorVersion, header)
+
+	name := root.Path
+	if abs, err := filepath.Abs(root.Path); err == nil {
+		name = abs
+	}
+
+	if err := writeItem(out, root, name); err != nil {
+		return err
+	}
+	out.WriteString("]\n")
+
+	return out.Flush()
+}
+
+func writeItem(out *bufio.Writer, item *models.Item, name string) error {
+	entry := info{Name: name}
+	if !item.ModTime.IsZero() {
+		entry.MTime = item.ModTime.Unix()
+	}
+
+	if item.ItemType != models.ItemTypeDirectory {
+		if item.Size != nil {
+			entry.ASize, entry.DSize = item.Size.Size, item.Size.Size
+		}
+
+		return writeInfo(out, entry)
+	}
+
+	out.WriteByte('[')
+	if err := writeInfo(out, entry); err != nil {
+		return err
+	}
+	for _, child := range item.Children {
+		out.WriteString(",\n")
+		if err := writeItem(out, child, child.Name); err != nil {
+			return err
+		}
+	}
+	out.WriteByte(']')
+
+	return nil
+}
+
+func writeInfo(out *bufio.Writer, entry info) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(data)
+
+	return err
+}
+
+// Load reads an ncdu JSON dump (as written by `ncdu -o`) and builds the models.Item tree from it.
+// The sizes of files are their apparent sizes (or the disk usage if the apparent size is missing),
+// directories have the total size of their content, like a MemSpace scan. Entries excluded by ncdu
+// are left out. The returned root item is marked as root.
+//
+// Parameters:
+//   - path: The dump file to read.
+//
+// Returns:
+//   - *models.Item: The root item of the tree.
+//   - error: An error if the file cannot be read, decoded or has an unsupported version.
+func Load(path string) (*models.Item, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	root, err := Read(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ncdu dump: %w", err)
+	}
+
+	return root, nil
+}
+
+// Read decodes an ncdu JSON dump like Load, but from a reader. The dump is decoded token by token,
+// so only the resulting tree is held in memory.
+//
+// Parameters:
+//   - r: The reader the dump is read from.
+//
+// Returns:
+//   - *models.Item: The root item of the tree.
+//   - error: An error if the dump cannot be decoded or has an unsupported version.
+func Read(r io.Reader) (*models.Item, error) {
+	decoder := json.NewDecoder(r)
+
+	if err := expect(decoder, '['); err != nil {
+		return nil, err
+	}
+
+	var major, minor int
+	if err := decoder.Decode(&major); err != nil {
+		return nil, err
+	}
+	if major != MajorVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, major)
+	}
+	if err := decoder.Decode(&minor); err != nil {
+		return nil, err
+	}
+
+	var header metadata
+	if err := decoder.Decode(&header); err != nil {
+		return nil, err
+	}
+
+	if err := expect(decoder, '['); err != nil {
+		return nil, err
+	}
+
+	root, err := readDirectory(decoder, "")
+	if err != nil {
+		return nil, err
+	}
+	root.Root = true
+
+	return root, nil
+}
+
+// readDirectory reads a directory whose opening bracket was already consumed, including its closing bracket.
+func readDirectory(decoder *json.Decoder, parent string) (*models.Item, error) {
+	if err := expect(decoder, '{'); err != nil {
+		return nil, err
+	}
+
+	entry, err := readInfo(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	path := entry.Name
+	name := filepath.Base(entry.Name)
+	if parent != "" {
+		path, name = filepath.Join(parent, entry.Name), entry.Name
+	}
+
+	dir := models.NewItemWithSize(name, path, models.ItemTypeDirectory, unit.NewFromBytes(0))
+	if entry.MTime != 0 {
+		dir.ModTime = time.Unix(entry.MTime, 0)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var child *models.Item
+		switch token {
+		case json.Delim('['):
+			if child, err = readDirectory(decoder, path); err != nil {
+				return nil, err
+			}
+		case json.Delim('{'):
+			childEntry, err := readInfo(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if childEntry.Excluded != "" {
+				continue
+			}
+
+			size := childEntry.ASize
+			if size == 0 {
+				size = childEntry.DSize
+			}
+			child = models.NewItemWithSize(childEntry.Name, filepath.Join(path, childEntry.Name), models.ItemTypeFile, unit.NewFromBytes(size))
+			if childEntry.MTime != 0 {
+				child.ModTime = time.Unix(childEntry.MTime, 0)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %v in directory %s", token, path)
+		}
+
+		dir.Children = append(dir.Children, child)
+		dir.Size.Add(child.Size)
+	}
+
+	if err := expect(decoder, ']'); err != nil {
+		return nil, err
+	}
+
+	return dir, nil
+}
+
+// readInfo reads the fields of an object whose opening brace was already consumed, including its closing brace.
+// Unknown fields are skipped.
+func readInfo(decoder *json.Decoder) (info, error) {
+	var entry info
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return entry, err
+		}
+
+		var value any
+		switch token {
+		case "name":
+			value = &entry.Name
+		case "asize":
+			value = &entry.ASize
+		case "dsize":
+			value = &entry.DSize
+		case "mtime":
+			value = &entry.MTime
+		case "excluded":
+			value = &entry.Excluded
+		default:
+			value = &json.RawMessage{}
+		}
+
+		if err := decoder.Decode(value); err != nil {
+			return entry, err
+		}
+	}
+
+	return entry, expect(decoder, '}')
+}
+
+func expect(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+
+	return nil
+}
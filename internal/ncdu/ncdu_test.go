@@ -0,0 +1,111 @@
+package ncdu
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	t.Parallel()
+
+	root := models.NewItemWithSize("root", "/data/root", models.ItemTypeDirectory, unit.NewFromBytes(3000))
+	root.Root = true
+	root.ModTime = time.Unix(1700000000, 0)
+	dir := models.NewItemWithSize("dir", "/data/root/dir", models.ItemTypeDirectory, unit.NewFromBytes(2000))
+	dir.Children = append(dir.Children, models.NewItemWithSize("big", "/data/root/dir/big", models.ItemTypeFile, unit.NewFromBytes(2000)))
+	root.Children = append(root.Children, models.NewItemWithSize("small", "/data/root/small", models.ItemTypeFile, unit.NewFromBytes(1000)), dir)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, root))
+	assert.True(t, strings.HasPrefix(buf.String(), "[1,2,{\"progname\":\"MemSpace\""))
+
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		dump      string
+		want      *models.Item
+		expectErr bool
+	}{
+		{
+			name: "Dump written by ncdu",
+			dump: `[1,2,{"progname":"ncdu","progver":"1.19","timestamp":1700000000},
+[{"name":"/srv","asize":4096,"dsize":4096,"dev":2049,"ino":2},
+{"name":"a.log","asize":100,"dsize":4096,"ino":3},
+{"name":"sparse","dsize":8192,"ino":4,"hlnkc":true},
+{"name":"proc","excluded":"kernfs"},
+[{"name":"empty","asize":4096,"dsize":4096,"ino":5,"read_error":true}]]]`,
+			want: func() *models.Item {
+				root := models.NewItemWithSize("srv", "/srv", models.ItemTypeDirectory, unit.NewFromBytes(8292))
+				root.Root = true
+				root.Children = []*models.Item{
+					models.NewItemWithSize("a.log", "/srv/a.log", models.ItemTypeFile, unit.NewFromBytes(100)),
+					models.NewItemWithSize("sparse", "/srv/sparse", models.ItemTypeFile, unit.NewFromBytes(8192)),
+					models.NewItemWithSize("empty", "/srv/empty", models.ItemTypeDirectory, unit.NewFromBytes(0)),
+				}
+				return root
+			}(),
+		},
+		{
+			name:      "Unsupported major version",
+			dump:      `[2,0,{},[{"name":"/"}]]`,
+			expectErr: true,
+		},
+		{
+			name:      "Root is not a directory",
+			dump:      `[1,2,{},{"name":"/"}]`,
+			expectErr: true,
+		},
+		{
+			name:      "Truncated dump",
+			dump:      `[1,2,{},[{"name":"/"},{"name":"a"`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Read(strings.NewReader(tt.dump))
+			if tt.expectErr {
+				assert.Error(t, err, "Expected an error but got none")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dump.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[1,0,{},[{"name":"/tmp"},{"name":"f","asize":5}]]`), 0o644))
+
+	root, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), root.Size.Size)
+	assert.Equal(t, "/tmp/f", root.Children[0].Path)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
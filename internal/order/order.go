@@ -0,0 +1,183 @@
+package order
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Mode is the key the children of a directory are sorted by.
+type Mode string
+
+const (
+	// ModeSize sorts by size, largest first.
+	ModeSize Mode = "size"
+	// ModeName sorts by name, case-insensitive and alphabetical.
+	ModeName Mode = "name"
+	// ModeCount sorts by the number of files below an item, most first.
+	ModeCount Mode = "count"
+	// ModeModTime sorts by modification time, newest first.
+	ModeModTime Mode = "mtime"
+)
+
+// Sorter sorts the children of directories. Items with equal keys are ordered by name,
+// so the order is stable between runs.
+//
+// Fields:
+//   - Mode: The key items are sorted by.
+//   - Ascending: Sorts from the smallest key to the largest: smallest, fewest files or oldest first, names from A to Z.
+//     New sets it by default only for ModeName, so the other modes start with the largest key.
+//   - Natural: Compares numbers in names by value, so "file2" comes before "file10".
+type Sorter struct {
+	Mode      Mode
+	Ascending bool
+	Natural   bool
+
+	counts models.FileCounts
+}
+
+// New creates a Sorter for the given mode.
+//
+// Parameters:
+//   - mode: The key items are sorted by.
+//   - direction: "asc" or "desc" to force a direction, empty for the default of the mode
+//     (ascending for names, descending otherwise).
+//   - natural: A boolean indicating whether to compare numbers in names by value.
+//
+// Returns:
+//   - *Sorter: A new sorter.
+func New(mode Mode, direction string, natural bool) *Sorter {
+	ascending := mode == ModeName
+	switch direction {
+	case "asc":
+		ascending = true
+	case "desc":
+		ascending = false
+	}
+
+	return &Sorter{Mode: mode, Ascending: ascending, Natural: natural}
+}
+
+// Sort returns the given items in the order of the sorter. The given slice is not modified.
+func (s *Sorter) Sort(items []*models.Item) []*models.Item {
+	sorted := append([]*models.Item(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if s.Mode == ModeName {
+			if s.Ascending {
+				return s.nameLess(a, b)
+			}
+			return s.nameLess(b, a)
+		}
+
+		switch c := s.compare(a, b); {
+		case c < 0:
+			return s.Ascending
+		case c > 0:
+			return !s.Ascending
+		}
+
+		return s.nameLess(a, b)
+	})
+
+	return sorted
+}
+
+// compare returns -1, 0 or 1 if the key of a is smaller, equal or larger than the key of b.
+func (s *Sorter) compare(a, b *models.Item) int {
+	var x, y int64
+	switch s.Mode {
+	case ModeSize:
+		x, y = a.Bytes(), b.Bytes()
+	case ModeCount:
+		x, y = int64(s.counts.Of(a)), int64(s.counts.Of(b))
+	case ModeModTime:
+		x, y = a.ModTime.UnixNano(), b.ModTime.UnixNano()
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+func (s *Sorter) nameLess(a, b *models.Item) bool {
+	if s.Natural {
+		return NaturalLess(a.Name, b.Name)
+	}
+
+	if x, y := strings.ToLower(a.Name), strings.ToLower(b.Name); x != y {
+		return x < y
+	}
+
+	return a.Name < b.Name
+}
+
+// NaturalLess reports whether a sorts before b in natural (version-aware) order. Names are compared
+// case-insensitively in chunks of digits and non-digits, where digit chunks are compared by value,
+// e.g. "v1.9" < "v1.10" and "file2" < "file10".
+//
+// Parameters:
+//   - a: The first name.
+//   - b: The second name.
+//
+// Returns:
+//   - bool: True if a sorts before b.
+func NaturalLess(a, b string) bool {
+	x, y := []rune(a), []rune(b)
+	for len(x) > 0 && len(y) > 0 {
+		if unicode.IsDigit(x[0]) && unicode.IsDigit(y[0]) {
+			var numX, numY []rune
+			numX, x = digits(x)
+			numY, y = digits(y)
+
+			// Compare by value: without leading zeros, the longer number is larger.
+			trimX, trimY := trimZeros(numX), trimZeros(numY)
+			if len(trimX) != len(trimY) {
+				return len(trimX) < len(trimY)
+			}
+			if string(trimX) != string(trimY) {
+				return string(trimX) < string(trimY)
+			}
+			if len(numX) != len(numY) {
+				return len(numX) < len(numY)
+			}
+			continue
+		}
+
+		if cx, cy := unicode.ToLower(x[0]), unicode.ToLower(y[0]); cx != cy {
+			return cx < cy
+		}
+		x, y = x[1:], y[1:]
+	}
+
+	if len(x) != len(y) {
+		return len(x) < len(y)
+	}
+
+	return a < b
+}
+
+// digits splits the leading digits off s.
+func digits(s []rune) ([]rune, []rune) {
+	i := 0
+	for i < len(s) && unicode.IsDigit(s[i]) {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+func trimZeros(s []rune) []rune {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+
+	return s
+}
@@ -0,0 +1,138 @@
+package order
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testItems returns the files "b10" (100 bytes, oldest), "B2" (300 bytes) and "a" (100 bytes, newest)
+// and the directory "c" (50 bytes) containing two files.
+func testItems() []*models.Item {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b10 := models.NewItemWithSize("b10", "b10", models.ItemTypeFile, unit.NewFromBytes(100))
+	b10.ModTime = base
+	b2 := models.NewItemWithSize("B2", "B2", models.ItemTypeFile, unit.NewFromBytes(300))
+	b2.ModTime = base.Add(time.Hour)
+	a := models.NewItemWithSize("a", "a", models.ItemTypeFile, unit.NewFromBytes(100))
+	a.ModTime = base.Add(3 * time.Hour)
+	c := models.NewItemWithSize("c", "c", models.ItemTypeDirectory, unit.NewFromBytes(50))
+	c.ModTime = base.Add(2 * time.Hour)
+	c.Children = append(c.Children,
+		models.NewItemWithSize("x", "c/x", models.ItemTypeFile, unit.NewFromBytes(25)),
+		models.NewItemWithSize("y", "c/y", models.ItemTypeFile, unit.NewFromBytes(25)))
+
+	return []*models.Item{b10, b2, a, c}
+}
+
+func names(items []*models.Item) []string {
+	result := []string{}
+	for _, item := range items {
+		result = append(result, item.Name)
+	}
+	return result
+}
+
+func TestSorter_Sort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mode      Mode
+		direction string
+		natural   bool
+		want      []string
+	}{
+		{name: "Size, default descending", mode: ModeSize, want: []string{"B2", "a", "b10", "c"}},
+		{name: "Size ascending", mode: ModeSize, direction: "asc", want: []string{"c", "a", "b10", "B2"}},
+		{name: "Name, default ascending", mode: ModeName, want: []string{"a", "b10", "B2", "c"}},
+		{name: "Name descending", mode: ModeName, direction: "desc", want: []string{"c", "B2", "b10", "a"}},
+		{name: "Natural name", mode: ModeName, natural: true, want: []string{"a", "B2", "b10", "c"}},
+		{name: "File count", mode: ModeCount, want: []string{"c", "a", "b10", "B2"}},
+		{name: "Modification time, default newest first", mode: ModeModTime, want: []string{"a", "c", "B2", "b10"}},
+		{name: "Modification time ascending", mode: ModeModTime, direction: "asc", want: []string{"b10", "B2", "c", "a"}},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items := testItems()
+			sorter := New(tt.mode, tt.direction, tt.natural)
+			assert.Equal(t, tt.want, names(sorter.Sort(items)))
+			assert.Equal(t, []string{"b10", "B2", "a", "c"}, names(items), "The given slice is not modified")
+		})
+	}
+}
+
+func TestSorter_Sort_ScannedModTime(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	for name, year := range map[string]int{"mid": 2015, "new": 2025, "old": 2001} {
+		path := filepath.Join(base, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o644))
+		mtime := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	_, err := utils.Rescan(root, base, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"new", "mid", "old"}, names(New(ModeModTime, "", false).Sort(root.Children)))
+	assert.Equal(t, []string{"old", "mid", "new"}, names(New(ModeModTime, "asc", false).Sort(root.Children)))
+}
+
+func TestNaturalLess(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "Numbers by value",
+			input: []string{"file10", "file2", "file1"},
+			want:  []string{"file1", "file2", "file10"},
+		},
+		{
+			name:  "Versions",
+			input: []string{"v1.10.0", "v1.9.2", "v1.9.10", "v2"},
+			want:  []string{"v1.9.2", "v1.9.10", "v1.10.0", "v2"},
+		},
+		{
+			name:  "Leading zeros",
+			input: []string{"img010", "img9", "img10"},
+			want:  []string{"img9", "img10", "img010"},
+		},
+		{
+			name:  "Case-insensitive and prefixes",
+			input: []string{"Beta", "alpha", "alpha2", "alpha10", "alph"},
+			want:  []string{"alph", "alpha", "alpha2", "alpha10", "Beta"},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := append([]string(nil), tt.input...)
+			sort.Slice(got, func(i, j int) bool { return NaturalLess(got[i], got[j]) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
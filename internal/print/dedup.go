@@ -0,0 +1,41 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/dedup"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Dedup prints the actions executed or planned by dedup.Apply or dedup.Undo, followed by
+// a summary of the affected bytes.
+//
+// Parameters:
+//   - actions: The actions to print, skipped ones are printed with their reason.
+//   - dryRun: A boolean indicating whether the actions were only planned.
+//   - undo: A boolean indicating whether the actions were reverted instead of applied.
+func Dedup(actions []dedup.Action, dryRun bool, undo bool) {
+	verb, summary := "replaced", "reclaimed"
+	if undo {
+		verb, summary = "restored", "restored"
+	}
+	if dryRun {
+		verb, summary = "would be "+verb, "would be "+summary
+	}
+
+	count := 0
+	for _, action := range actions {
+		if action.Skipped != "" {
+			fmt.Printf("%s %s: %s\n", color.YellowString("skipped"), color.BlueString(action.Duplicate), action.Skipped)
+			continue
+		}
+
+		count++
+		fmt.Printf("%s %s (%s of %s) [%s]\n", color.GreenString(verb), color.BlueString(action.Duplicate),
+			action.Method, action.Original, color.YellowString(unit.NewFromBytes(action.Size).RawSizeString()))
+	}
+
+	fmt.Printf("%d files %s, %s %s\n", count, verb, color.RedString(unit.NewFromBytes(dedup.Reclaimed(actions)).RawSizeString()), summary)
+}
@@ -0,0 +1,110 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/diff"
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Diff prints a visual representation of a compared tree as produced by diff.Compare.
+// Only items that changed are printed; children are printed in the order of the node,
+// which is by absolute delta (largest first).
+//
+// Parameters:
+//   - node: The root node of the compared tree.
+//   - recursive: A boolean indicating whether to traverse the tree recursively.
+//   - dirOnly: A boolean indicating whether to include only directories in the output.
+//   - depth: A pointer to an integer specifying the maximum depth to traverse. If nil, no depth limit is applied.
+//   - threshold: A pointer to a unit.Size specifying the minimum absolute delta of items to include. If nil, no threshold is applied.
+//   - currentDepth: An integer representing the current depth of traversal (used internally for recursion).
+//
+// Behavior:
+//   - The root is printed with its old and new size and the delta when currentDepth is 0.
+//   - Added items are prefixed with a green "+", removed items with a red "-" and changed items with a cyan "~".
+//   - Growth is printed as a green "+" delta and shrinkage as a red "-" delta.
+//
+// Example:
+//
+//	Diff(diff.Compare(before, after), true, false, nil, nil, 0)
+func Diff(node *diff.Node, recursive bool, dirOnly bool, depth *int, threshold *unit.Size, currentDepth int) {
+	if currentDepth == 0 {
+		fmt.Printf("📁%s [%s → %s] %s\n", color.GreenString(node.Name),
+			color.YellowString(unit.NewFromBytes(node.OldSize).RawSizeString()),
+			color.YellowString(unit.NewFromBytes(node.NewSize).RawSizeString()),
+			deltaString(node.Delta()))
+	}
+
+	diffLines(node, recursive, dirOnly, depth, threshold, currentDepth, "")
+}
+
+// diffLines prints the changed children of node, descending into directories.
+// indent holds the connectors of the ancestors, which continue only if more siblings follow.
+func diffLines(node *diff.Node, recursive bool, dirOnly bool, depth *int, threshold *unit.Size, currentDepth int, indent string) {
+	if (!recursive && currentDepth > 0) || (depth != nil && currentDepth > *depth) {
+		return
+	}
+
+	children := make([]*diff.Node, 0, len(node.Children))
+	for _, child := range node.Children {
+		if child.Status == diff.StatusUnchanged ||
+			(dirOnly && child.ItemType != models.ItemTypeDirectory) ||
+			(threshold != nil && child.AbsDelta() < threshold.Size) {
+			continue
+		}
+		children = append(children, child)
+	}
+
+	for i, child := range children {
+		prefix, next := indent+"│-", indent+"│ "
+		if i == len(children)-1 {
+			prefix, next = indent+"└-", indent+"  "
+		}
+
+		icon, name := "📄", color.BlueString(child.Name)
+		if child.ItemType == models.ItemTypeDirectory {
+			icon, name = "📁", color.GreenString(child.Name)
+		}
+
+		size := child.NewSize
+		if child.Status == diff.StatusRemoved {
+			size = child.OldSize
+		}
+
+		fmt.Printf("%s%s%s%s [%s] %s\n", prefix, statusString(child.Status), icon, name,
+			color.YellowString(unit.NewFromBytes(size).RawSizeString()), deltaString(child.Delta()))
+
+		if child.ItemType == models.ItemTypeDirectory {
+			diffLines(child, recursive, dirOnly, depth, threshold, currentDepth+1, next)
+		}
+	}
+}
+
+func statusString(status diff.Status) string {
+	switch status {
+	case diff.StatusAdded:
+		return color.GreenString("+")
+	case diff.StatusRemoved:
+		return color.RedString("-")
+	case diff.StatusChanged:
+		return color.CyanString("~")
+	case diff.StatusUnchanged:
+		return " "
+	}
+
+	return " "
+}
+
+func deltaString(delta int64) string {
+	switch {
+	case delta > 0:
+		return color.GreenString("+%s", unit.NewFromBytes(delta).RawSizeString())
+	case delta < 0:
+		return color.RedString("-%s", unit.NewFromBytes(-delta).RawSizeString())
+	}
+
+	return "±0.00B"
+}
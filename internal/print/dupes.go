@@ -0,0 +1,36 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/dupes"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Duplicates prints the duplicate sets as returned by dupes.Find, followed by a summary
+// of the total reclaimable space.
+//
+// Parameters:
+//   - sets: The duplicate sets, sorted by wasted bytes.
+func Duplicates(sets []dupes.Set) {
+	var wasted int64
+	for _, set := range sets {
+		wasted += set.Wasted()
+
+		fmt.Printf("%d copies of %s, %s reclaimable [sha256:%s]\n", len(set.Paths),
+			color.YellowString(unit.NewFromBytes(set.Size).RawSizeString()),
+			color.RedString(unit.NewFromBytes(set.Wasted()).RawSizeString()), set.Hash[:12])
+
+		for i, path := range set.Paths {
+			prefix := "│-"
+			if i == len(set.Paths)-1 {
+				prefix = "└-"
+			}
+			fmt.Printf("%s📄%s\n", prefix, color.BlueString(path))
+		}
+	}
+
+	fmt.Printf("%d duplicate sets, %s reclaimable\n", len(sets), color.RedString(unit.NewFromBytes(wasted).RawSizeString()))
+}
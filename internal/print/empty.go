@@ -0,0 +1,50 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/empty"
+
+	"github.com/fatih/color"
+)
+
+// Empty prints the zero-byte files and empty directories as returned by empty.Find.
+//
+// Parameters:
+//   - result: The empty items to print.
+func Empty(result empty.Result) {
+	for _, file := range result.Files {
+		fmt.Printf("📄%s\n", color.BlueString(file.Path))
+	}
+
+	for _, dir := range result.Directories {
+		fmt.Printf("📁%s\n", color.GreenString(dir.Path))
+	}
+
+	fmt.Printf("%d empty files, %d empty directories\n", len(result.Files), len(result.Directories))
+}
+
+// Removals prints the removals executed or planned by empty.Remove.
+//
+// Parameters:
+//   - removals: The removals to print, skipped ones are printed with their reason.
+//   - dryRun: A boolean indicating whether the removals were only planned.
+func Removals(removals []empty.Removal, dryRun bool) {
+	verb := "removed"
+	if dryRun {
+		verb = "would be removed"
+	}
+
+	count := 0
+	for _, removal := range removals {
+		if removal.Skipped != "" {
+			fmt.Printf("%s %s: %s\n", color.YellowString("skipped"), color.BlueString(removal.Item.Path), removal.Skipped)
+			continue
+		}
+
+		count++
+		fmt.Printf("%s %s\n", color.RedString(verb), removal.Item.Path)
+	}
+
+	fmt.Printf("%d items %s\n", count, verb)
+}
@@ -0,0 +1,40 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/chunk"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Estimate prints a table of the block-level deduplication estimate as returned by chunk.Estimate,
+// with one row per top-level entry followed by the total of the whole tree.
+//
+// Parameters:
+//   - report: The estimate to print.
+func Estimate(report *chunk.Report) {
+	fmt.Printf("%10s  %10s  %8s  %s\n", "TOTAL", "UNIQUE", "SAVINGS", "NAME")
+	for _, entry := range report.Entries {
+		estimateRow(entry, color.GreenString("📁%s", entry.Name))
+	}
+	estimateRow(report.Usage, "total")
+
+	fmt.Printf("%d chunks, %d unique (average chunk size %s)\n", report.Chunks, report.UniqueChunks, averageChunk(report.Usage))
+}
+
+func estimateRow(usage chunk.Usage, name string) {
+	fmt.Printf("%s  %s  %7.2f%%  %s\n",
+		color.YellowString("%10s", unit.NewFromBytes(usage.Total).RawSizeString()),
+		color.GreenString("%10s", unit.NewFromBytes(usage.Unique).RawSizeString()),
+		usage.Savings()*100, name)
+}
+
+func averageChunk(usage chunk.Usage) string {
+	if usage.Chunks == 0 {
+		return unit.NewFromBytes(0).RawSizeString()
+	}
+
+	return unit.NewFromBytes(usage.Total / int64(usage.Chunks)).RawSizeString()
+}
@@ -0,0 +1,50 @@
+package print
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/growth"
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Growth prints a table of the fastest growing items as returned by growth.Rates.
+//
+// Parameters:
+//   - rates: The growing items, sorted by growth rate.
+//   - limit: The maximum number of rows to print.
+//   - dirOnly: A boolean indicating whether to include only directories in the output.
+//   - elapsed: The time between the compared scans.
+func Growth(rates []growth.Rate, limit int, dirOnly bool, elapsed time.Duration) {
+	fmt.Printf("Growth over the last %s (%s)\n", elapsed.Round(time.Millisecond), time.Now().Format(time.TimeOnly))
+	fmt.Printf("%12s  %10s  %10s  %s\n", "RATE", "DELTA", "SIZE", "PATH")
+
+	rows := 0
+	for _, rate := range rates {
+		if rows >= limit {
+			break
+		}
+
+		if dirOnly && rate.ItemType != models.ItemTypeDirectory {
+			continue
+		}
+		rows++
+
+		icon, path := "📄", color.BlueString(rate.Path)
+		if rate.ItemType == models.ItemTypeDirectory {
+			icon, path = "📁", color.GreenString(rate.Path)
+		}
+
+		fmt.Printf("%s  %s  %10s  %s%s\n",
+			color.RedString("%12s", unit.NewFromBytes(int64(rate.BytesPerSecond)).RawSizeString()+"/s"),
+			color.YellowString("%10s", "+"+unit.NewFromBytes(rate.Delta).RawSizeString()),
+			unit.NewFromBytes(rate.Size).RawSizeString(), icon, path)
+	}
+
+	if rows == 0 {
+		fmt.Println("Nothing grew")
+	}
+}
@@ -0,0 +1,61 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/MemSpace/internal/histogram"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+const histogramBarWidth = 30
+
+// Histogram prints a file size histogram as an ASCII bar chart, with one bar for the
+// number of files and one for the number of bytes in each bucket.
+//
+// Parameters:
+//   - h: The histogram to print.
+func Histogram(h *histogram.Histogram) {
+	fmt.Printf("📁%s [%d files, %s]\n", color.GreenString(h.Path), h.Files, color.YellowString(unit.NewFromBytes(h.Bytes).RawSizeString()))
+	fmt.Printf("%-8s %10s %*s %10s\n", "SIZE", "FILES", histogramBarWidth+8, "", "BYTES")
+
+	for _, bucket := range h.Buckets {
+		fmt.Printf("%-8s %10d %s %10s %s\n", bucket.Label,
+			bucket.Files, histogramBar(bucket.Files, h.Files, color.BlueString),
+			unit.NewFromBytes(bucket.Bytes).RawSizeString(), histogramBar(bucket.Bytes, h.Bytes, color.YellowString))
+	}
+}
+
+// HistogramTSV prints file size histograms as tab-separated values with a header line,
+// one row per directory and bucket. The upper bound of the last bucket is empty.
+//
+// Parameters:
+//   - histograms: The histograms to print.
+func HistogramTSV(histograms []*histogram.Histogram) {
+	fmt.Println("path\tbucket\tupper_bytes\tfiles\tbytes")
+	for _, h := range histograms {
+		for _, bucket := range h.Buckets {
+			upper := ""
+			if bucket.Upper >= 0 {
+				upper = fmt.Sprint(bucket.Upper)
+			}
+			fmt.Printf("%s\t%s\t%s\t%d\t%d\n", h.Path, bucket.Label, upper, bucket.Files, bucket.Bytes)
+		}
+	}
+}
+
+func histogramBar(value, total int64, colorize func(string, ...interface{}) string) string {
+	var share float64
+	if total > 0 {
+		share = float64(value) / float64(total)
+	}
+
+	width := int(share*histogramBarWidth + 0.5)
+	if width == 0 && value > 0 {
+		width = 1
+	}
+
+	return fmt.Sprintf("%s%s %6.2f%%", colorize(strings.Repeat("#", width)), strings.Repeat(" ", histogramBarWidth-width), share*100)
+}
@@ -0,0 +1,44 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/remove"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Removed prints the actions executed or planned by remove.Apply, followed by
+// a summary of the reclaimed bytes.
+//
+// Parameters:
+//   - actions: The actions to print, skipped ones are printed with their reason.
+//   - dryRun: A boolean indicating whether the actions were only planned.
+func Removed(actions []remove.Action, dryRun bool) {
+	verb, summary := "deleted", "reclaimed"
+	if len(actions) > 0 && actions[0].Method == remove.MethodTrash {
+		verb = "trashed"
+	}
+	if dryRun {
+		verb, summary = "would be "+verb, "would be "+summary
+	}
+
+	count := 0
+	for _, action := range actions {
+		if action.Skipped != "" {
+			fmt.Printf("%s %s: %s\n", color.YellowString("skipped"), color.BlueString(action.Path), action.Skipped)
+			continue
+		}
+
+		count++
+		line := fmt.Sprintf("%s %s [%s]", color.RedString(verb), color.BlueString(action.Path),
+			color.YellowString(unit.NewFromBytes(action.Size).RawSizeString()))
+		if action.Location != "" {
+			line += " -> " + action.Location
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Printf("%d items %s, %s %s\n", count, verb, color.RedString(unit.NewFromBytes(remove.Reclaimed(actions)).RawSizeString()), summary)
+}
@@ -0,0 +1,42 @@
+package print
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/top"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// Top prints the largest files and/or directories of a ranking as a flat list with full paths.
+//
+// Parameters:
+//   - ranking: The ranking holding the largest entries.
+//   - kind: Which entries to print, "files", "dirs" or "all" for both.
+func Top(ranking *top.Ranking, kind string) {
+	if kind != "dirs" {
+		topEntries("Largest files", ranking.Files())
+	}
+
+	if kind == "all" {
+		fmt.Println()
+	}
+
+	if kind != "files" {
+		topEntries("Largest directories", ranking.Directories())
+	}
+}
+
+func topEntries(title string, entries []top.Entry) {
+	fmt.Println(title)
+	for _, entry := range entries {
+		icon, path := "📄", color.BlueString(entry.Path)
+		if entry.ItemType == models.ItemTypeDirectory {
+			icon, path = "📁", color.GreenString(entry.Path)
+		}
+
+		fmt.Printf("%s  %s%s\n", color.YellowString("%10s", unit.NewFromBytes(entry.Size).RawSizeString()), icon, path)
+	}
+}
@@ -3,57 +3,212 @@ package print
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/StevenCyb/MemSpace/internal/filter"
 	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/order"
 	"github.com/StevenCyb/MemSpace/internal/unit"
 
 	"github.com/fatih/color"
 )
 
+// treeBarWidth is the width of the size bar of a tree line in columns.
+const treeBarWidth = 20
+
+// treeBarEighths holds the partial blocks of a size bar, indexed by eighths of a column.
+var treeBarEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// TreeOptions configures which items Tree prints and which columns are shown next to them.
+//
+// Fields:
+//   - Recursive: Traverses the tree recursively instead of printing only the children of the root.
+//   - DirectoryOnly: Includes only directories.
+//   - Depth: The maximum depth to traverse (0 prints only the children of the root). If nil, no depth limit is applied.
+//   - Threshold: The minimum size of items to include. If nil, no size threshold is applied.
+//   - Percent: Shows the share of the parent and of the root directory in a right-hand column.
+//   - Bar: Shows a bar proportional to the share of the root directory in a right-hand column.
+//   - Sort: Sorts the children of every directory. If nil, they are printed in scan order.
+//   - MaxChildren: The maximum number of children printed per directory, the smaller ones are collapsed
+//     into a single "others" line. 0 prints all children.
+//   - MinPercent: The minimum share of their directory in percent of children that are printed individually,
+//     the smaller ones are collapsed into a single "others" line. 0 prints all children.
+type TreeOptions struct {
+	Recursive     bool
+	DirectoryOnly bool
+	Depth         *int
+	Threshold     *unit.Size
+	Percent       bool
+	Bar           bool
+	Sort          *order.Sorter
+	MaxChildren   int
+	MinPercent    float64
+}
+
+// treeLine is a printed line of the tree with the values of its right-hand columns.
+type treeLine struct {
+	text   string
+	width  int
+	size   int64
+	parent int64
+}
+
 // Tree prints a visual representation of a directory tree structure starting from the given item.
 // It supports recursive traversal, filtering by directory-only items, and limiting depth or size thresholds.
 //
 // Parameters:
 //   - item: The root item of the tree to be printed. It must be of type *models.Item.
-//   - recursive: A boolean indicating whether to traverse the tree recursively.
-//   - dirOnly: A boolean indicating whether to include only directories in the output.
-//   - depth: A pointer to an integer specifying the maximum depth to traverse. If nil, no depth limit is applied.
-//   - threshold: A pointer to a unit.Size specifying the minimum size of items to include. If nil, no size threshold is applied.
-//   - currentDepth: An integer representing the current depth of traversal (used internally for recursion).
+//   - opts: The items to include and the columns to show.
 //
 // Behavior:
 //   - If the item is marked as the root, it prints the root directory with its size.
 //   - Traverses the children of the item and prints them with appropriate prefixes to indicate tree structure.
-//   - Applies the depth and size thresholds to filter items.
-//   - If dirOnly is true, only directories are included in the output.
+//   - Applies the depth and size thresholds to filter items. Ancestors of matching items are kept, so the
+//     tree stays connected, and directories note how many of their children were hidden.
+//   - If Sort is set, the children of every directory are printed in its order.
+//   - If MaxChildren or MinPercent is set, the smaller children of a directory are collapsed into a single
+//     "… N others [size]" line, so the printed sizes still add up.
+//   - If DirectoryOnly is set, only directories are included in the output.
 //   - Uses visual indicators (e.g., "📁" for directories and "📄" for files) and colors for better readability.
+//   - If Percent or Bar is set, the columns are aligned right of the longest line, regardless of the nesting depth.
 //
 // Example:
 //
-//	Tree(rootItem, true, false, nil, nil, 0)
-func Tree(item *models.Item, recursive bool, dirOnly bool, depth *int, threshold *unit.Size, currentDepth int) {
+//	Tree(rootItem, TreeOptions{Recursive: true, Percent: true, Bar: true})
+func Tree(item *models.Item, opts TreeOptions) {
+	depth := opts.Depth
+	if !opts.Recursive {
+		depth = new(int)
+	}
+	result := filter.Apply(item, filter.Options{DirectoryOnly: opts.DirectoryOnly, Depth: depth, Threshold: opts.Threshold})
+
+	children, others := treeChildren(item, opts, result)
+	lines := []treeLine{}
 	if item.Root {
-		fmt.Printf("📁%s [%s]\n", color.GreenString(item.Name), color.YellowString(item.Size.RawSizeString()))
+		lines = append(lines, newTreeLine("", item, item, treeHidden(item, result, others)))
 	}
+	lines = treeLines(item, children, others, opts, result, "", lines)
 
-	if (!recursive && currentDepth > 0) || (depth != nil && currentDepth > *depth) {
+	if !opts.Percent && !opts.Bar {
+		for _, line := range lines {
+			fmt.Println(line.text)
+		}
 		return
 	}
 
-	for i, child := range item.Children {
-		isLast := i == len(item.Children)-1
-		prefix := "│-"
-		if isLast {
-			prefix = "└-"
+	width := 0
+	for _, line := range lines {
+		width = max(width, line.width)
+	}
+
+	total := item.Bytes()
+	for _, line := range lines {
+		columns := ""
+		if opts.Percent {
+			columns += fmt.Sprintf(" %6.1f%% %6.1f%%", treeShare(line.size, line.parent)*100, treeShare(line.size, total)*100)
 		}
+		if opts.Bar {
+			columns += " " + color.CyanString(treeBar(treeShare(line.size, total)))
+		}
+		fmt.Printf("%s%s %s\n", line.text, strings.Repeat(" ", width-line.width), columns)
+	}
+}
 
-		if child.ItemType == models.ItemTypeDirectory {
-			if (threshold == nil || threshold.Size <= child.Size.Size) && (depth == nil || currentDepth <= *depth) {
-				fmt.Printf("%s%s📁%s [%s]\n", strings.Repeat("│ ", currentDepth), prefix, color.GreenString(child.Name), color.YellowString(child.Size.RawSizeString()))
-			}
-			Tree(child, recursive, dirOnly, depth, threshold, currentDepth+1)
-		} else if !dirOnly && (threshold == nil || threshold.Size <= child.Size.Size) && (depth == nil || currentDepth <= *depth) {
-			fmt.Printf("%s%s📄%s [%s]\n", strings.Repeat("│ ", currentDepth), prefix, color.BlueString(child.Name), color.YellowString(child.Size.RawSizeString()))
+// treeLines appends the lines of the children of item, as selected by treeChildren, to lines, descending into
+// directories. indent holds the connectors of the ancestors, which continue only if more siblings follow.
+func treeLines(item *models.Item, children []*models.Item, others filter.Hidden, opts TreeOptions, result *filter.Result, indent string, lines []treeLine) []treeLine {
+	for i, child := range children {
+		prefix, next := indent+"│-", indent+"│ "
+		if i == len(children)-1 && others.Count == 0 {
+			prefix, next = indent+"└-", indent+"  "
 		}
+
+		grandchildren, grandothers := treeChildren(child, opts, result)
+		lines = append(lines, newTreeLine(prefix, child, item, treeHidden(child, result, grandothers)))
+		lines = treeLines(child, grandchildren, grandothers, opts, result, next, lines)
+	}
+
+	if others.Count > 0 {
+		size := unit.NewFromBytes(others.Size).RawSizeString()
+		text := fmt.Sprintf("… %s others [%s]", thousands(others.Count), size)
+		lines = append(lines, treeLine{
+			text:   indent + "└-" + color.HiBlackString(text),
+			width:  utf8.RuneCountInString(indent + "└-" + text),
+			size:   others.Size,
+			parent: item.Bytes(),
+		})
+	}
+
+	return lines
+}
+
+// treeChildren returns the surviving children of item that are printed individually, in print order,
+// and the summary of the others, which includes the children hidden by the filter.
+func treeChildren(item *models.Item, opts TreeOptions, result *filter.Result) ([]*models.Item, filter.Hidden) {
+	children := item.Children
+	if opts.Sort != nil {
+		children = opts.Sort.Sort(children)
+	}
+
+	return filter.Collapse(result.Children(children), result.Hidden(item), item.Bytes(), opts.MaxChildren, opts.MinPercent/100)
+}
+
+// treeHidden returns the children of item hidden by the filter that are noted on its line,
+// none if they are already part of its "others" line.
+func treeHidden(item *models.Item, result *filter.Result, others filter.Hidden) filter.Hidden {
+	if others.Count > 0 {
+		return filter.Hidden{}
+	}
+
+	return result.Hidden(item)
+}
+
+// thousands formats n with commas as thousands separators, e.g. 4,213.
+func thousands(n int) string {
+	digits := fmt.Sprint(n)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return digits
+}
+
+// newTreeLine formats the line of item below parent, noting the children hidden by the filter.
+func newTreeLine(prefix string, item, parent *models.Item, hidden filter.Hidden) treeLine {
+	icon, name := "📄", color.BlueString(item.Name)
+	if item.ItemType == models.ItemTypeDirectory {
+		icon, name = "📁", color.GreenString(item.Name)
 	}
+
+	size := unit.NewFromBytes(item.Bytes()).RawSizeString()
+	text := fmt.Sprintf("%s%s%s [%s]", prefix, icon, name, color.YellowString(size))
+	width := utf8.RuneCountInString(prefix+item.Name+size) + 5 // the icon takes two columns, plus " []"
+
+	if hidden.Count > 0 {
+		note := fmt.Sprintf(" (%d hidden, %s)", hidden.Count, unit.NewFromBytes(hidden.Size).RawSizeString())
+		text += color.HiBlackString(note)
+		width += utf8.RuneCountInString(note)
+	}
+
+	return treeLine{
+		text:   text,
+		width:  width,
+		size:   item.Bytes(),
+		parent: parent.Bytes(),
+	}
+}
+
+// treeBar returns a bar of up to treeBarWidth columns filled according to share, in steps of an eighth column.
+func treeBar(share float64) string {
+	eighths := int(share*treeBarWidth*8 + 0.5)
+
+	return strings.Repeat("█", eighths/8) + treeBarEighths[eighths%8]
+}
+
+func treeShare(size, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(size) / float64(total)
 }
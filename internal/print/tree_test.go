@@ -0,0 +1,179 @@
+package print
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stdoutMutex serializes the tests that redirect os.Stdout, which is shared by the whole process.
+var stdoutMutex sync.Mutex
+
+// capture returns the lines fn prints to os.Stdout, without colors.
+func capture(t *testing.T, fn func()) []string {
+	t.Helper()
+
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe")
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	stdout, noColor := os.Stdout, color.NoColor
+	os.Stdout, color.NoColor = writer, true
+	func() {
+		defer func() { os.Stdout, color.NoColor = stdout, noColor }()
+		fn()
+	}()
+	writer.Close()
+
+	return strings.Split(strings.TrimSuffix(<-output, "\n"), "\n")
+}
+
+// columns returns the terminal width of line, where the file and directory icons take two columns.
+func columns(line string) int {
+	return utf8.RuneCountInString(line) + strings.Count(line, "📁") + strings.Count(line, "📄")
+}
+
+func testTree() *models.Item {
+	return &models.Item{Root: true, Name: "root", Path: "root", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(1000), Children: []*models.Item{
+		{Name: "docs", Path: "root/docs", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(600), Children: []*models.Item{
+			{Name: "a.md", Path: "root/docs/a.md", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(150)},
+			{Name: "b.md", Path: "root/docs/b.md", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(450)},
+		}},
+		{Name: "main.go", Path: "root/main.go", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(400)},
+	}}
+}
+
+func TestTree(t *testing.T) {
+	t.Parallel()
+
+	zero := &models.Item{Root: true, Name: "empty", Path: "empty", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(0), Children: []*models.Item{
+		{Name: "a", Path: "empty/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(0)},
+	}}
+
+	tests := []struct {
+		name     string
+		item     *models.Item
+		opts     TreeOptions
+		expected []string
+	}{
+		{
+			name: "Without columns",
+			item: testTree(),
+			opts: TreeOptions{Recursive: true},
+			expected: []string{
+				"📁root [1000.00B]",
+				"│-📁docs [600.00B]",
+				"│ │-📄a.md [150.00B]",
+				"│ └-📄b.md [450.00B]",
+				"└-📄main.go [400.00B]",
+			},
+		},
+		{
+			name: "Percent of parent and root",
+			item: testTree(),
+			opts: TreeOptions{Recursive: true, Percent: true},
+			expected: []string{
+				"📁root [1000.00B]       100.0%  100.0%",
+				"│-📁docs [600.00B]       60.0%   60.0%",
+				"│ │-📄a.md [150.00B]     25.0%   15.0%",
+				"│ └-📄b.md [450.00B]     75.0%   45.0%",
+				"└-📄main.go [400.00B]    40.0%   40.0%",
+			},
+		},
+		{
+			name: "Bar of the root share",
+			item: testTree(),
+			opts: TreeOptions{Recursive: true, Bar: true},
+			expected: []string{
+				"📁root [1000.00B]      " + strings.Repeat("█", 20),
+				"│-📁docs [600.00B]     " + strings.Repeat("█", 12),
+				"│ │-📄a.md [150.00B]   ███",
+				"│ └-📄b.md [450.00B]   █████████",
+				"└-📄main.go [400.00B]  ████████",
+			},
+		},
+		{
+			name: "Partial bar blocks",
+			item: &models.Item{Root: true, Name: "r", Path: "r", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(1000), Children: []*models.Item{
+				{Name: "a", Path: "r/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(975)},
+				{Name: "b", Path: "r/b", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(25)},
+			}},
+			opts: TreeOptions{Recursive: true, Bar: true},
+			expected: []string{
+				"📁r [1000.00B]   " + strings.Repeat("█", 20),
+				"│-📄a [975.00B]  " + strings.Repeat("█", 19) + "▌",
+				"└-📄b [25.00B]   ▌",
+			},
+		},
+		{
+			name: "Collapsed children have a share",
+			item: &models.Item{Root: true, Name: "r", Path: "r", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(1000), Children: []*models.Item{
+				{Name: "a", Path: "r/a", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(700)},
+				{Name: "b", Path: "r/b", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(200)},
+				{Name: "c", Path: "r/c", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(100)},
+			}},
+			opts: TreeOptions{Recursive: true, Percent: true, MaxChildren: 1},
+			expected: []string{
+				"📁r [1000.00B]           100.0%  100.0%",
+				"│-📄a [700.00B]           70.0%   70.0%",
+				"└-… 2 others [300.00B]    30.0%   30.0%",
+			},
+		},
+		{
+			name: "Zero-size root",
+			item: zero,
+			opts: TreeOptions{Recursive: true, Percent: true, Bar: true},
+			expected: []string{
+				"📁empty [0.00B]     0.0%    0.0% ",
+				"└-📄a [0.00B]       0.0%    0.0% ",
+			},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := capture(t, func() { Tree(tt.item, tt.opts) })
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestTree_ColumnsAligned(t *testing.T) {
+	t.Parallel()
+
+	root := testTree()
+	root.Children[0].Children[0].Children = []*models.Item{
+		{Name: "a-very-long-file-name.md", Path: "root/docs/a.md/a-very-long-file-name.md", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(150)},
+	}
+	root.Children[0].Children[0].ItemType = models.ItemTypeDirectory
+
+	lines := capture(t, func() { Tree(root, TreeOptions{Recursive: true, Percent: true, Bar: true}) })
+	require.Len(t, lines, 6)
+
+	for _, line := range lines {
+		index := strings.Index(line, "%")
+		require.NotEqual(t, -1, index, "Expected a percent column in %q", line)
+		assert.Equal(t, columns(lines[0][:strings.Index(lines[0], "%")]), columns(line[:index]), "Expected the columns of %q to be aligned", line)
+	}
+}
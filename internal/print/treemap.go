@@ -0,0 +1,152 @@
+package print
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/StevenCyb/MemSpace/internal/layout"
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/fatih/color"
+)
+
+// treemapPalette holds the background colors of treemap cells. Siblings get different colors,
+// nested levels alternate between the normal and the bright variant.
+var treemapPalette = [][2]color.Attribute{
+	{color.BgBlue, color.BgHiBlue},
+	{color.BgGreen, color.BgHiGreen},
+	{color.BgCyan, color.BgHiCyan},
+	{color.BgMagenta, color.BgHiMagenta},
+	{color.BgYellow, color.BgHiYellow},
+	{color.BgRed, color.BgHiRed},
+}
+
+// treemapFill holds the characters cells are filled with if colors are disabled, one per palette entry.
+var treemapFill = []rune{'░', '▒', '▓', '█', '▚', '▞'}
+
+// treemapCell is a character of the treemap with the palette entry and nesting level of its item.
+type treemapCell struct {
+	char  rune
+	color int
+	level int
+}
+
+type treemapRect struct {
+	x, y, w, h int
+}
+
+// Treemap prints the tree below item as a squarified treemap of colored blocks with the given size in
+// terminal cells. Items are labeled with name and size where they fit, directories large enough show
+// their content inside. As terminal cells are about twice as high as wide, the layout is computed with
+// doubled heights, so the blocks look square.
+//
+// Parameters:
+//   - item: The root item of the tree to draw.
+//   - dirOnly: A boolean indicating whether to include only directories.
+//   - depth: A pointer to an integer specifying the maximum depth to draw (0 draws only the children of item). If nil, no depth limit is applied.
+//   - threshold: A pointer to a unit.Size specifying the minimum size of items to draw. If nil, no size threshold is applied.
+//   - width: The width of the treemap in terminal columns.
+//   - height: The height of the treemap in terminal rows, excluding the title line.
+func Treemap(item *models.Item, dirOnly bool, depth *int, threshold *unit.Size, width, height int) {
+	fmt.Printf("📁%s [%s]\n", color.GreenString(item.Name), color.YellowString(unit.NewFromBytes(item.Bytes()).RawSizeString()))
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	grid := make([][]treemapCell, height)
+	for y := range grid {
+		grid[y] = make([]treemapCell, width)
+		for x := range grid[y] {
+			grid[y][x] = treemapCell{char: ' ', color: -1}
+		}
+	}
+
+	var draw func(item *models.Item, bounds treemapRect, currentDepth int)
+	draw = func(item *models.Item, bounds treemapRect, currentDepth int) {
+		if depth != nil && currentDepth > *depth {
+			return
+		}
+
+		children := []*models.Item{}
+		for _, child := range item.Children {
+			if (!dirOnly || child.ItemType == models.ItemTypeDirectory) && (threshold == nil || threshold.Size <= child.Bytes()) {
+				children = append(children, child)
+			}
+		}
+
+		// Colors are assigned in the order of the layout, so neighboring blocks get different colors.
+		sort.SliceStable(children, func(a, b int) bool { return children[a].Bytes() > children[b].Bytes() })
+
+		// The layout only stays inside the bounds if the total covers all children, which sizes of
+		// imported or partially removed trees do not guarantee.
+		total := float64(item.Bytes())
+		weights := make([]float64, len(children))
+		sum := 0.0
+		for i, child := range children {
+			weights[i] = float64(child.Bytes())
+			sum += max(weights[i], 0)
+		}
+		total = max(total, sum)
+
+		area := layout.Rect{X: float64(bounds.x), Y: float64(bounds.y * 2), W: float64(bounds.w), H: float64(bounds.h * 2)}
+		for i, rect := range layout.Squarify(weights, total, area) {
+			cell := treemapRect{x: int(math.Round(rect.X)), y: int(math.Round(rect.Y / 2))}
+			cell.w, cell.h = int(math.Round(rect.X+rect.W))-cell.x, int(math.Round((rect.Y+rect.H)/2))-cell.y
+			if cell.w <= 0 || cell.h <= 0 {
+				continue
+			}
+
+			child := children[i]
+			fill := treemapCell{char: ' ', color: (i + currentDepth) % len(treemapPalette), level: currentDepth}
+			if color.NoColor {
+				fill.char = treemapFill[fill.color]
+			}
+			for y := cell.y; y < cell.y+cell.h; y++ {
+				for x := cell.x; x < cell.x+cell.w; x++ {
+					grid[y][x] = fill
+				}
+			}
+
+			label := []rune(fmt.Sprintf("%s %s", child.Name, unit.NewFromBytes(child.Bytes()).RawSizeString()))
+			if len(label) > cell.w {
+				label = []rune(child.Name)
+			}
+			if len(label) > cell.w {
+				label = append(label[:max(cell.w-1, 0)], '…')
+			}
+			if cell.w >= 2 {
+				for j, char := range label {
+					grid[cell.y][cell.x+j].char = char
+				}
+			}
+
+			if child.ItemType == models.ItemTypeDirectory && cell.w >= 4 && cell.h >= 3 {
+				draw(child, treemapRect{x: cell.x + 1, y: cell.y + 1, w: cell.w - 1, h: cell.h - 1}, currentDepth+1)
+			}
+		}
+	}
+	draw(item, treemapRect{w: width, h: height}, 0)
+
+	for _, row := range grid {
+		var line strings.Builder
+		for x := 0; x < len(row); {
+			end := x
+			var run strings.Builder
+			for end < len(row) && row[end].color == row[x].color && row[end].level == row[x].level {
+				run.WriteRune(row[end].char)
+				end++
+			}
+
+			if row[x].color < 0 {
+				line.WriteString(run.String())
+			} else {
+				line.WriteString(color.New(treemapPalette[row[x].color][row[x].level%2], color.FgBlack).Sprint(run.String()))
+			}
+			x = end
+		}
+		fmt.Println(line.String())
+	}
+}
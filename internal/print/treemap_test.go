@@ -0,0 +1,169 @@
+package print
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreemap(t *testing.T) {
+	t.Parallel()
+
+	zero := 0
+
+	tests := []struct {
+		name      string
+		item      *models.Item
+		depth     *int
+		threshold *unit.Size
+		dirOnly   bool
+		width     int
+		height    int
+		expected  []string
+	}{
+		{
+			name:   "Nested layout",
+			item:   testTree(),
+			width:  30,
+			height: 6,
+			expected: []string{
+				"📁root [1000.00B]",
+				"docs 600.00B░░░░░░main.go▒▒▒▒▒",
+				"░b.md 450.00B▒a.md▒▒▒▒▒▒▒▒▒▒▒▒",
+				"░▒▒▒▒▒▒▒▒▒▒▒▒▒▓▓▓▓▒▒▒▒▒▒▒▒▒▒▒▒",
+				"░▒▒▒▒▒▒▒▒▒▒▒▒▒▓▓▓▓▒▒▒▒▒▒▒▒▒▒▒▒",
+				"░▒▒▒▒▒▒▒▒▒▒▒▒▒▓▓▓▓▒▒▒▒▒▒▒▒▒▒▒▒",
+				"░▒▒▒▒▒▒▒▒▒▒▒▒▒▓▓▓▓▒▒▒▒▒▒▒▒▒▒▒▒",
+			},
+		},
+		{
+			name:   "Labels are truncated to the cell",
+			item:   testTree(),
+			width:  11,
+			height: 3,
+			expected: []string{
+				"📁root [1000.00B]",
+				"docs░░░mai…",
+				"░b.md▒▓▒▒▒▒",
+				"░▒▒▒▒▒▓▒▒▒▒",
+			},
+		},
+		{
+			name:   "Depth limit",
+			item:   testTree(),
+			depth:  &zero,
+			width:  30,
+			height: 3,
+			expected: []string{
+				"📁root [1000.00B]",
+				"docs 600.00B░░░░░░main.go▒▒▒▒▒",
+				"░░░░░░░░░░░░░░░░░░▒▒▒▒▒▒▒▒▒▒▒▒",
+				"░░░░░░░░░░░░░░░░░░▒▒▒▒▒▒▒▒▒▒▒▒",
+			},
+		},
+		{
+			name:      "Threshold leaves the space of small items empty",
+			item:      testTree(),
+			threshold: unit.NewFromBytes(500),
+			width:     30,
+			height:    3,
+			expected: []string{
+				"📁root [1000.00B]",
+				"docs 600.00B░░░░░░            ",
+				"░░░░░░░░░░░░░░░░░░            ",
+				"░░░░░░░░░░░░░░░░░░            ",
+			},
+		},
+		{
+			name:    "Directories only",
+			item:    testTree(),
+			dirOnly: true,
+			depth:   &zero,
+			width:   30,
+			height:  2,
+			expected: []string{
+				"📁root [1000.00B]",
+				"docs 600.00B░░░░░░            ",
+				"░░░░░░░░░░░░░░░░░░            ",
+			},
+		},
+		{
+			name: "Children larger than their parent",
+			item: &models.Item{Name: "r", Path: "r", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(10), Children: []*models.Item{
+				{Name: "x", Path: "r/x", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(100)},
+				{Name: "y", Path: "r/y", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(100)},
+			}},
+			width:  7,
+			height: 2,
+			expected: []string{
+				"📁r [10.00B]",
+				"x░░░y▒▒",
+				"░░░░▒▒▒",
+			},
+		},
+		{
+			name:     "Empty area",
+			item:     testTree(),
+			width:    0,
+			height:   5,
+			expected: []string{"📁root [1000.00B]"},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := capture(t, func() { Treemap(tt.item, tt.dirOnly, tt.depth, tt.threshold, tt.width, tt.height) })
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestTreemap_OddSizes(t *testing.T) {
+	t.Parallel()
+
+	// A deep chain of directories, each with a file and a directory, and a tree with inconsistent sizes.
+	chain := models.NewItemWithSize("chain", "chain", models.ItemTypeDirectory, unit.NewFromBytes(1023))
+	for current, size := chain, int64(1023); size > 1; size /= 2 {
+		dir := models.NewItemWithSize("d", current.Path+"/d", models.ItemTypeDirectory, unit.NewFromBytes(size/2))
+		current.Children = append(current.Children, dir, models.NewItemWithSize("f", current.Path+"/f", models.ItemTypeFile, unit.NewFromBytes(size-size/2)))
+		current = dir
+	}
+	inconsistent := &models.Item{Name: "r", Path: "r", ItemType: models.ItemTypeDirectory, Children: []*models.Item{
+		{Name: "a", Path: "r/a", ItemType: models.ItemTypeDirectory, Size: unit.NewFromBytes(3), Children: []*models.Item{
+			{Name: "b", Path: "r/a/b", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(7)},
+			{Name: "c", Path: "r/a/c", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(5)},
+		}},
+		{Name: "d", Path: "r/d", ItemType: models.ItemTypeFile, Size: unit.NewFromBytes(1)},
+	}}
+
+	for _, item := range []*models.Item{testTree(), chain, inconsistent} {
+		for width := 0; width <= 13; width++ {
+			for height := 0; height <= 7; height++ {
+				name := fmt.Sprintf("%s %dx%d", item.Name, width, height)
+
+				var lines []string
+				assert.NotPanics(t, func() {
+					lines = capture(t, func() { Treemap(item, false, nil, nil, width, height) })
+				}, name)
+
+				if width == 0 || height == 0 {
+					assert.Len(t, lines, 1, name)
+					continue
+				}
+				if assert.Len(t, lines, height+1, name) {
+					for _, line := range lines[1:] {
+						assert.Equal(t, width, utf8.RuneCountInString(line), "%s: %q", name, line)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package print
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/watch"
+
+	"github.com/fatih/color"
+)
+
+// ClearScreen clears the terminal and moves the cursor to the top left corner,
+// so that the next output replaces the previous one.
+func ClearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
+
+// WatchStatus prints a status line of the watch mode.
+// If directories could not be watched, a warning about the polled directories is printed as well.
+//
+// Parameters:
+//   - stats: The watch statistics as reported by watch.Run.
+//   - interval: The refresh interval of the watch mode.
+func WatchStatus(stats watch.Stats, interval time.Duration) {
+	fmt.Printf("Watching %s directories, refreshing every %s (%s)\n",
+		color.GreenString("%d", stats.Watched), interval, time.Now().Format(time.TimeOnly))
+
+	if stats.Polled > 0 {
+		fmt.Println(color.YellowString("%d directories could not be watched and are re-read every %s "+
+			"(consider raising fs.inotify.max_user_watches)", stats.Polled, interval))
+	}
+}
@@ -0,0 +1,143 @@
+package remove
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/trash"
+)
+
+// Method is the way an item is removed.
+type Method string
+
+const (
+	// MethodDelete deletes an item permanently, including all content of a directory.
+	MethodDelete Method = "delete"
+	// MethodTrash moves an item into the trash, from where it can be restored.
+	MethodTrash Method = "trash"
+)
+
+// Action describes the removal of an item of a scanned tree.
+//
+// Fields:
+//   - Method: The way the item was removed.
+//   - Path: The path of the item.
+//   - ItemType: The type of the item.
+//   - Size: The size of the item in bytes, which is reclaimed by deleting it.
+//   - Location: The path of the item in the trash, empty if it was deleted or not removed.
+//   - Skipped: The reason why the item was not removed, empty if it was (or would be in a dry run).
+type Action struct {
+	Method   Method
+	Path     string
+	ItemType models.ItemType
+	Size     int64
+	Location string
+	Skipped  string
+}
+
+// Apply removes the items with the given paths from the file system and from the tree below root,
+// whose sizes are updated up to the root. Paths that are not part of the tree are skipped, as is the
+// root itself. Paths inside another given path and repeated paths are skipped, so every item is only
+// counted once, no matter the order. Items that no longer exist are skipped but still removed from the tree.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//   - paths: The paths of the items to remove, as stored in the tree.
+//   - method: The way the items are removed.
+//   - dryRun: If set, the removals are only planned and neither the file system nor the tree is changed.
+//
+// Returns:
+//   - []Action: All planned actions, including skipped ones with their reason.
+//   - error: An error if an item cannot be removed.
+func Apply(root *models.Item, paths []string, method Method, dryRun bool) ([]Action, error) {
+	// Only items below the root can include other paths, decided before the tree is changed.
+	removable := map[string]bool{}
+	cleaned := make([]string, 0, len(paths))
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		cleaned = append(cleaned, path)
+		removable[path] = len(root.Find(path)) > 1
+	}
+
+	actions := make([]Action, 0, len(paths))
+	for i, path := range cleaned {
+		action := Action{Method: method, Path: path}
+
+		lineage := root.Find(path)
+		action.Skipped = covered(cleaned, i, removable)
+		switch {
+		case action.Skipped != "":
+		case len(lineage) == 0:
+			action.Skipped = "not part of the scan"
+		case len(lineage) == 1:
+			action.Skipped = "the base path cannot be removed"
+		}
+		if action.Skipped != "" {
+			actions = append(actions, action)
+			continue
+		}
+
+		item := lineage[len(lineage)-1]
+		action.ItemType = item.ItemType
+		action.Size = item.Bytes()
+
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			action.Skipped = "no longer exists"
+		} else if err != nil {
+			return actions, err
+		}
+
+		if !dryRun {
+			if action.Skipped == "" {
+				var err error
+				if method == MethodTrash {
+					action.Location, err = trash.Move(path)
+				} else {
+					err = os.RemoveAll(path)
+				}
+				if err != nil {
+					return actions, fmt.Errorf("failed to %s %s: %w", method, path, err)
+				}
+			}
+
+			root.Detach(path)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// covered returns why paths[i] is skipped because another removable path already includes it, empty if it is not.
+func covered(paths []string, i int, removable map[string]bool) string {
+	for j, other := range paths {
+		switch {
+		case j == i || !removable[other]:
+		case other == paths[i]:
+			if j < i {
+				return "listed more than once"
+			}
+		case strings.HasPrefix(paths[i], other+string(filepath.Separator)):
+			return "included in " + other
+		}
+	}
+
+	return ""
+}
+
+// Reclaimed returns the number of bytes freed (or to be freed) by the given actions.
+// Items moved into the trash are included, as they no longer count towards the scanned tree.
+func Reclaimed(actions []Action) int64 {
+	var total int64
+	for _, action := range actions {
+		if action.Skipped == "" {
+			total += action.Size
+		}
+	}
+
+	return total
+}
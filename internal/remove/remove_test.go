@@ -0,0 +1,147 @@
+package remove
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scan creates base/dir/big (20 bytes) and base/small (5 bytes) and returns the scanned tree.
+func scan(t *testing.T, base string) *models.Item {
+	t.Helper()
+
+	require.NoError(t, os.Mkdir(filepath.Join(base, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "big"), make([]byte, 20), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "small"), make([]byte, 5), 0o644))
+
+	root := models.NewItem(utils.GetName(base), base, models.ItemTypeDirectory)
+	root.Root = true
+	_, err := utils.Rescan(root, base, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(25), root.Size.Size)
+
+	return root
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		paths     func(base string) []string
+		dryRun    bool
+		wantSize  int64
+		reclaimed int64
+		skipped   []string
+		removed   []string
+	}{
+		{
+			name: "Delete files in different directories",
+			paths: func(base string) []string {
+				return []string{filepath.Join(base, "dir", "big"), filepath.Join(base, "small")}
+			},
+			wantSize:  0,
+			reclaimed: 25,
+			skipped:   []string{"", ""},
+			removed:   []string{"dir/big", "small"},
+		},
+		{
+			name:      "Delete directory",
+			paths:     func(base string) []string { return []string{filepath.Join(base, "dir") + "/"} },
+			wantSize:  5,
+			reclaimed: 20,
+			skipped:   []string{""},
+			removed:   []string{"dir"},
+		},
+		{
+			name:      "Dry run",
+			paths:     func(base string) []string { return []string{filepath.Join(base, "dir")} },
+			dryRun:    true,
+			wantSize:  25,
+			reclaimed: 20,
+			skipped:   []string{""},
+		},
+		{
+			name: "Dry run counts nested paths once",
+			paths: func(base string) []string {
+				return []string{filepath.Join(base, "dir"), filepath.Join(base, "dir", "big")}
+			},
+			dryRun:    true,
+			wantSize:  25,
+			reclaimed: 20,
+			skipped:   []string{"", "included in $BASE/dir"},
+		},
+		{
+			name: "Nested path before its directory",
+			paths: func(base string) []string {
+				return []string{filepath.Join(base, "dir", "big"), filepath.Join(base, "dir"), filepath.Join(base, "dir")}
+			},
+			wantSize:  5,
+			reclaimed: 20,
+			skipped:   []string{"included in $BASE/dir", "", "listed more than once"},
+			removed:   []string{"dir"},
+		},
+		{
+			name: "Skip unknown paths and the root",
+			paths: func(base string) []string {
+				return []string{filepath.Join(base, "unknown"), base, filepath.Join(t.TempDir(), "x")}
+			},
+			wantSize:  25,
+			reclaimed: 0,
+			skipped:   []string{"not part of the scan", "the base path cannot be removed", "not part of the scan"},
+		},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			base := t.TempDir()
+			root := scan(t, base)
+
+			actions, err := Apply(root, tt.paths(base), MethodDelete, tt.dryRun)
+			require.NoError(t, err)
+
+			skipped := []string{}
+			for _, action := range actions {
+				skipped = append(skipped, strings.ReplaceAll(action.Skipped, base, "$BASE"))
+			}
+			assert.Equal(t, tt.skipped, skipped)
+			assert.Equal(t, tt.reclaimed, Reclaimed(actions))
+			assert.Equal(t, tt.wantSize, root.Size.Size, "Sizes are updated up to the root")
+
+			for _, path := range tt.removed {
+				assert.NoFileExists(t, filepath.Join(base, path))
+				assert.Nil(t, root.Find(filepath.Join(base, path)), "Removed items are detached from the tree")
+			}
+		})
+	}
+}
+
+func TestApply_Trash(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(t.TempDir(), "data"))
+	root := scan(t, base)
+
+	actions, err := Apply(root, []string{filepath.Join(base, "dir", "big")}, MethodTrash, false)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	assert.FileExists(t, actions[0].Location)
+	assert.NoFileExists(t, filepath.Join(base, "dir", "big"))
+	assert.Equal(t, int64(5), root.Size.Size)
+	assert.Equal(t, int64(0), root.Find(filepath.Join(base, "dir"))[1].Size.Size)
+
+	require.NoError(t, os.Remove(filepath.Join(base, "small")))
+	actions, err = Apply(root, []string{filepath.Join(base, "small")}, MethodTrash, false)
+	require.NoError(t, err)
+	assert.Equal(t, "no longer exists", actions[0].Skipped)
+	assert.Equal(t, int64(0), root.Size.Size, "Items that no longer exist are detached")
+}
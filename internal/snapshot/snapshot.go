@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+)
+
+// Version is the schema version written to new snapshot files.
+const Version = 1
+
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// Snapshot is the on-disk representation of a scan.
+//
+// Fields:
+//   - Version: The schema version of the snapshot file.
+//   - CreatedAt: The point in time the snapshot was taken.
+//   - BasePath: The base path that was scanned.
+//   - Root: The root node of the scanned tree.
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	BasePath  string    `json:"base_path"`
+	Root      *Node     `json:"root"`
+}
+
+// Node is the serialized form of a models.Item.
+// Times are stored as Unix nanoseconds and omitted if unknown.
+type Node struct {
+	Name       string  `json:"name"`
+	Path       string  `json:"path"`
+	Type       string  `json:"type"`
+	Size       int64   `json:"size"`
+	ModTime    int64   `json:"mtime,omitempty"`
+	ChangeTime int64   `json:"ctime,omitempty"`
+	Children   []*Node `json:"children,omitempty"`
+}
+
+// Save writes the tree below root as a snapshot to the file at path.
+// An existing file is overwritten.
+//
+// Parameters:
+//   - path: The file the snapshot is written to.
+//   - basePath: The base path the tree was scanned from.
+//   - root: The root item of the scanned tree.
+//
+// Returns:
+//   - error: An error if the file cannot be created or written.
+func Save(path, basePath string, root *models.Item) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	snap := Snapshot{
+		Version:   Version,
+		CreatedAt: time.Now(),
+		BasePath:  basePath,
+		Root:      fromItem(root),
+	}
+
+	if err := json.NewEncoder(file).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	return file.Close()
+}
+
+// Load reads a snapshot written by Save and rebuilds the models.Item tree from it.
+// The returned root item is marked as root.
+//
+// Parameters:
+//   - path: The snapshot file to read.
+//
+// Returns:
+//   - *models.Item: The root item of the restored tree.
+//   - error: An error if the file cannot be read, decoded or has an unsupported version.
+func Load(path string) (*models.Item, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snap Snapshot
+	if err := json.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if snap.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, snap.Version)
+	}
+
+	if snap.Root == nil {
+		return nil, fmt.Errorf("failed to decode snapshot: missing root")
+	}
+
+	root := toItem(snap.Root)
+	root.Root = true
+
+	return root, nil
+}
+
+func fromItem(item *models.Item) *Node {
+	node := &Node{
+		Name: item.Name,
+		Path: item.Path,
+		Type: item.ItemType.String(),
+	}
+
+	if item.Size != nil {
+		node.Size = item.Size.Size
+	}
+
+	if !item.ModTime.IsZero() {
+		node.ModTime = item.ModTime.UnixNano()
+	}
+
+	if !item.ChangeTime.IsZero() {
+		node.ChangeTime = item.ChangeTime.UnixNano()
+	}
+
+	for _, child := range item.Children {
+		node.Children = append(node.Children, fromItem(child))
+	}
+
+	return node
+}
+
+func toItem(node *Node) *models.Item {
+	itemType := models.ItemTypeFile
+	if node.Type == models.ItemTypeDirectory.String() {
+		itemType = models.ItemTypeDirectory
+	}
+
+	item := models.NewItemWithSize(node.Name, node.Path, itemType, unit.NewFromBytes(node.Size))
+	if node.ModTime != 0 {
+		item.ModTime = time.Unix(0, node.ModTime)
+	}
+
+	if node.ChangeTime != 0 {
+		item.ChangeTime = time.Unix(0, node.ChangeTime)
+	}
+
+	for _, child := range node.Children {
+		item.Children = append(item.Children, toItem(child))
+	}
+
+	return item
+}
@@ -0,0 +1,59 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	root := models.NewItemWithSize("data", "data", models.ItemTypeDirectory, unit.NewFromBytes(5))
+	root.Root = true
+	dir := models.NewItemWithSize("c", "data/c", models.ItemTypeDirectory, unit.NewFromBytes(3))
+	dir.ModTime, dir.ChangeTime = time.Unix(1700000000, 1), time.Unix(1700000001, 2)
+	dir.Children = append(dir.Children, models.NewItemWithSize("d.dat", "data/c/d.dat", models.ItemTypeFile, unit.NewFromBytes(3)))
+	root.Children = append(root.Children, models.NewItemWithSize("a", "data/a", models.ItemTypeFile, unit.NewFromBytes(2)), dir)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	assert.NoError(t, Save(path, "data", root), "Unexpected error occurred")
+
+	got, err := Load(path)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, root, got, "Loaded tree does not match")
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Invalid JSON", content: "{"},
+		{name: "Unsupported version", content: `{"version": 99, "root": {"name": "a"}}`},
+		{name: "Missing root", content: `{"version": 1}`},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "snapshot.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write snapshot: %v", err)
+			}
+
+			_, err := Load(path)
+			assert.Error(t, err, "Expected an error but got none")
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package top
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Entry is a ranked file or directory.
+type Entry struct {
+	Path     string
+	ItemType models.ItemType
+	Size     int64
+}
+
+// Ranking keeps the N largest files and directories added to it. Each type is held in a
+// min-heap bounded to N entries, so memory stays constant no matter how many entries are added.
+type Ranking struct {
+	limit int
+	files entries
+	dirs  entries
+}
+
+// New creates a Ranking keeping the limit largest files and the limit largest directories.
+func New(limit int) *Ranking {
+	return &Ranking{limit: limit}
+}
+
+// Add offers an entry to the ranking. It is kept if it is among the largest of its type so far.
+func (r *Ranking) Add(entry Entry) {
+	h := &r.files
+	if entry.ItemType == models.ItemTypeDirectory {
+		h = &r.dirs
+	}
+
+	if h.Len() < r.limit {
+		heap.Push(h, entry)
+	} else if h.Len() > 0 && less((*h)[0], entry) {
+		(*h)[0] = entry
+		heap.Fix(h, 0)
+	}
+}
+
+// AddTree offers every item below root to the ranking. The root itself is skipped.
+func (r *Ranking) AddTree(root *models.Item) {
+	for _, child := range root.Children {
+		var size int64
+		if child.Size != nil {
+			size = child.Size.Size
+		}
+
+		r.Add(Entry{Path: child.Path, ItemType: child.ItemType, Size: size})
+		r.AddTree(child)
+	}
+}
+
+// Files returns the largest files, sorted by size (largest first).
+func (r *Ranking) Files() []Entry {
+	return sorted(r.files)
+}
+
+// Directories returns the largest directories, sorted by size (largest first).
+func (r *Ranking) Directories() []Entry {
+	return sorted(r.dirs)
+}
+
+func sorted(h entries) []Entry {
+	result := append([]Entry{}, h...)
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[j], result[i])
+	})
+
+	return result
+}
+
+// less orders entries by size and, for equal sizes, by reversed path,
+// so the ranking is deterministic and ties are listed alphabetically.
+func less(a, b Entry) bool {
+	if a.Size != b.Size {
+		return a.Size < b.Size
+	}
+
+	return a.Path > b.Path
+}
+
+// entries implements heap.Interface as a min-heap.
+type entries []Entry
+
+func (e entries) Len() int           { return len(e) }
+func (e entries) Less(i, j int) bool { return less(e[i], e[j]) }
+func (e entries) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e *entries) Push(x any)        { *e = append(*e, x.(Entry)) }
+func (e *entries) Pop() any {
+	old := *e
+	entry := old[len(old)-1]
+	*e = old[:len(old)-1]
+
+	return entry
+}
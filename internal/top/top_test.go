@@ -0,0 +1,44 @@
+package top
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRanking_Add(t *testing.T) {
+	t.Parallel()
+
+	ranking := New(3)
+	for i, size := range []int64{5, 1, 9, 3, 7, 9, 2} {
+		ranking.Add(Entry{Path: fmt.Sprintf("file%d", i), ItemType: models.ItemTypeFile, Size: size})
+	}
+	ranking.Add(Entry{Path: "dir", ItemType: models.ItemTypeDirectory, Size: 100})
+
+	expected := []Entry{
+		{Path: "file2", ItemType: models.ItemTypeFile, Size: 9},
+		{Path: "file5", ItemType: models.ItemTypeFile, Size: 9},
+		{Path: "file4", ItemType: models.ItemTypeFile, Size: 7},
+	}
+	assert.Equal(t, expected, ranking.Files(), "Largest files do not match")
+	assert.Equal(t, []Entry{{Path: "dir", ItemType: models.ItemTypeDirectory, Size: 100}}, ranking.Directories())
+}
+
+func TestRanking_AddTree(t *testing.T) {
+	t.Parallel()
+
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(6))
+	dir := models.NewItemWithSize("dir", "root/dir", models.ItemTypeDirectory, unit.NewFromBytes(5))
+	dir.Children = append(dir.Children, models.NewItemWithSize("big", "root/dir/big", models.ItemTypeFile, unit.NewFromBytes(5)))
+	root.Children = append(root.Children, models.NewItemWithSize("small", "root/small", models.ItemTypeFile, unit.NewFromBytes(1)), dir)
+
+	ranking := New(1)
+	ranking.AddTree(root)
+
+	assert.Equal(t, []Entry{{Path: "root/dir/big", ItemType: models.ItemTypeFile, Size: 5}}, ranking.Files())
+	assert.Equal(t, []Entry{{Path: "root/dir", ItemType: models.ItemTypeDirectory, Size: 5}}, ranking.Directories())
+}
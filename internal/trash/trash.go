@@ -0,0 +1,210 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+var ErrNoTrash = errors.New("no trash directory available on the file system")
+
+// Move moves the file or directory at path into the trash following the FreeDesktop.org (XDG) trash
+// specification, so it can be restored with any file manager. Items on the file system of the home
+// trash ($XDG_DATA_HOME/Trash) are moved there, items on other file systems into $topdir/.Trash/$uid,
+// if an administrator created a sticky $topdir/.Trash, or otherwise into $topdir/.Trash-$uid, where
+// $topdir is the mount point of the file system. Next to the item in "files", a "<name>.trashinfo" file
+// in "info" records the original path and the deletion date.
+//
+// Parameters:
+//   - path: The path of the item to move into the trash.
+//
+// Returns:
+//   - string: The path of the item in the trash.
+//   - error: An error if no trash directory is available or moving fails.
+func Move(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Lstat(abs)
+	if err != nil {
+		return "", err
+	}
+
+	dir, topdir, err := directory(abs, info)
+	if err != nil {
+		return "", err
+	}
+
+	for _, sub := range []string{"files", "info"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return "", err
+		}
+	}
+
+	// The trashinfo file is created exclusively first to reserve the name, as the specification requires.
+	name, infoFile, err := reserve(dir, filepath.Base(abs))
+	if err != nil {
+		return "", err
+	}
+
+	original := abs
+	if topdir != "" {
+		// Trash directories on other file systems store paths relative to their mount point.
+		if rel, err := filepath.Rel(topdir, abs); err == nil {
+			original = rel
+		}
+	}
+
+	_, err = fmt.Fprintf(infoFile, "[Trash Info]\nPath=%s\nDeletionDate=%s\n", escape(original), time.Now().Format("2006-01-02T15:04:05"))
+	if closeErr := infoFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(infoFile.Name())
+		return "", err
+	}
+
+	target := filepath.Join(dir, "files", name)
+	if err := os.Rename(abs, target); err != nil {
+		os.Remove(infoFile.Name())
+		return "", err
+	}
+
+	return target, nil
+}
+
+// directory returns the trash directory for the item at path and, for trash directories
+// other than the home trash, the mount point they belong to.
+func directory(path string, info os.FileInfo) (dir, topdir string, err error) {
+	home := homeTrash()
+	if home != "" && sameFileSystem(info, home) {
+		return home, "", nil
+	}
+
+	topdir = mountPoint(path, info)
+	uid := strconv.Itoa(os.Getuid())
+
+	admin := filepath.Join(topdir, ".Trash")
+	if adminInfo, err := os.Lstat(admin); err == nil && adminInfo.IsDir() && adminInfo.Mode()&os.ModeSticky != 0 {
+		candidate := filepath.Join(admin, uid)
+		if err := os.MkdirAll(candidate, 0o700); err == nil {
+			return candidate, topdir, nil
+		}
+	}
+
+	candidate := filepath.Join(topdir, ".Trash-"+uid)
+	if err := os.MkdirAll(candidate, 0o700); err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrNoTrash, err)
+	}
+	if candidateInfo, err := os.Lstat(candidate); err != nil || !candidateInfo.IsDir() {
+		return "", "", fmt.Errorf("%w: %s is not a directory", ErrNoTrash, candidate)
+	}
+
+	return candidate, topdir, nil
+}
+
+// homeTrash returns the home trash directory, empty if the home directory is unknown.
+func homeTrash() string {
+	data := os.Getenv("XDG_DATA_HOME")
+	if data == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		data = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(data, "Trash")
+}
+
+// sameFileSystem reports whether info belongs to the file system of dir, or the file system dir would be created on.
+func sameFileSystem(info os.FileInfo, dir string) bool {
+	id, ok := utils.GetFileID(info)
+	if !ok {
+		return true
+	}
+
+	for current := dir; ; current = filepath.Dir(current) {
+		if dirInfo, err := os.Stat(current); err == nil {
+			dirID, ok := utils.GetFileID(dirInfo)
+			return !ok || dirID.Device == id.Device
+		}
+		if current == filepath.Dir(current) {
+			return false
+		}
+	}
+}
+
+// mountPoint returns the topmost ancestor of path on the same file system.
+func mountPoint(path string, info os.FileInfo) string {
+	id, ok := utils.GetFileID(info)
+	if !ok {
+		return filepath.VolumeName(path) + string(filepath.Separator)
+	}
+
+	top := path
+	for current := filepath.Dir(path); ; current = filepath.Dir(current) {
+		parentInfo, err := os.Stat(current)
+		if err != nil {
+			break
+		}
+		if parentID, ok := utils.GetFileID(parentInfo); !ok || parentID.Device != id.Device {
+			break
+		}
+		top = current
+		if current == filepath.Dir(current) {
+			break
+		}
+	}
+
+	return top
+}
+
+// reserve creates the trashinfo file for name in the trash directory dir, adding a number to the
+// name if it is already taken, and returns the chosen name with the open file.
+func reserve(dir, name string) (string, *os.File, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 1; ; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s.%d%s", base, i, ext)
+		}
+
+		file, err := os.OpenFile(filepath.Join(dir, "info", candidate+".trashinfo"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		} else if err != nil {
+			return "", nil, err
+		}
+
+		if _, err := os.Lstat(filepath.Join(dir, "files", candidate)); err == nil {
+			// A leftover without trashinfo file, keep it.
+			file.Close()
+			os.Remove(file.Name())
+			continue
+		}
+
+		return candidate, file, nil
+	}
+}
+
+// escape encodes path like a URL path, as required for the Path key of trashinfo files.
+func escape(path string) string {
+	segments := strings.Split(filepath.ToSlash(path), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,56 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMove(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(base, "data"))
+
+	first := filepath.Join(base, "a b.txt")
+	require.NoError(t, os.WriteFile(first, []byte("first"), 0o644))
+
+	location, err := Move(first)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "data", "Trash", "files", "a b.txt"), location)
+	assert.NoFileExists(t, first)
+
+	content, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(content))
+
+	info, err := os.ReadFile(filepath.Join(base, "data", "Trash", "info", "a b.txt.trashinfo"))
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^\[Trash Info\]\nPath=`+regexp.QuoteMeta(filepath.ToSlash(base))+`/a%20b.txt\nDeletionDate=\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\n$`), string(info))
+
+	second := filepath.Join(base, "a b.txt")
+	require.NoError(t, os.WriteFile(second, []byte("second"), 0o644))
+
+	location, err = Move(second)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "data", "Trash", "files", "a b.2.txt"), location, "Names in the trash are unique")
+	assert.FileExists(t, filepath.Join(base, "data", "Trash", "info", "a b.2.txt.trashinfo"))
+
+	dir := filepath.Join(base, "dir")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	location, err = Move(dir)
+	require.NoError(t, err)
+	assert.DirExists(t, filepath.Join(location, "sub"))
+
+	_, err = Move(filepath.Join(base, "missing"))
+	assert.Error(t, err)
+}
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/home/user/a%20b/%C3%A4%25.txt", escape("/home/user/a b/ä%.txt"))
+	assert.Equal(t, "dir/file", escape("dir/file"))
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package tui
+
+import "os"
+
+// makeRaw is not supported on this platform and always returns ErrUnsupported.
+func makeRaw(_ int) (func() error, error) {
+	return nil, ErrUnsupported
+}
+
+// resized returns nil, as resizes cannot be observed on this platform.
+func resized() <-chan os.Signal {
+	return nil
+}
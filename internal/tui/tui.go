@@ -0,0 +1,474 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/remove"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+var ErrUnsupported = errors.New("the interactive mode is not supported on this platform")
+
+// barWidth is the width of the percentage bar of an entry.
+const barWidth = 20
+
+// Key is a key pressed by the user.
+type Key int
+
+const (
+	KeyUnknown Key = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyEnter
+	KeyBackspace
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyRune
+)
+
+// SortMode is the order of the entries of a directory.
+type SortMode int
+
+const (
+	// SortBySize sorts by size, largest first.
+	SortBySize SortMode = iota
+	// SortByName sorts by name, case-insensitive.
+	SortByName
+	// SortByCount sorts by the number of files, most first.
+	SortByCount
+)
+
+func (s SortMode) String() string {
+	return [...]string{"size", "name", "count"}[s]
+}
+
+// ViewMode selects the entries of a directory that are shown.
+type ViewMode int
+
+const (
+	// ViewAll shows files and directories.
+	ViewAll ViewMode = iota
+	// ViewDirectories shows only directories.
+	ViewDirectories
+	// ViewFiles shows only files.
+	ViewFiles
+)
+
+func (v ViewMode) String() string {
+	return [...]string{"all", "directories", "files"}[v]
+}
+
+// Browser is the state of the interactive browser of a scanned tree. It is independent of the
+// terminal: keys are passed to Handle and Render returns the lines of the screen.
+//
+// Fields:
+//   - Root: The root item of the tree.
+//   - Dir: The directory whose entries are listed.
+//   - Cursor: The index of the selected entry.
+//   - Sort: The order of the entries.
+//   - View: The entries that are shown.
+//   - Status: A message shown in the last line, e.g. the result of a rescan.
+type Browser struct {
+	Root   *models.Item
+	Dir    *models.Item
+	Cursor int
+	Sort   SortMode
+	View   ViewMode
+	Status string
+
+	offset  int
+	page    int
+	counts  models.FileCounts
+	pending remove.Method
+	target  *models.Item
+}
+
+// NewBrowser creates a new Browser listing the entries of root.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - *Browser: A new browser.
+func NewBrowser(root *models.Item) *Browser {
+	return &Browser{Root: root, Dir: root, page: 1}
+}
+
+// Entries returns the shown entries of the current directory in the selected order.
+func (b *Browser) Entries() []*models.Item {
+	entries := make([]*models.Item, 0, len(b.Dir.Children))
+	for _, child := range b.Dir.Children {
+		isDir := child.ItemType == models.ItemTypeDirectory
+		if (b.View == ViewDirectories && !isDir) || (b.View == ViewFiles && isDir) {
+			continue
+		}
+		entries = append(entries, child)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, c := entries[i], entries[j]
+		switch b.Sort {
+		case SortBySize:
+			if a.Bytes() != c.Bytes() {
+				return a.Bytes() > c.Bytes()
+			}
+		case SortByCount:
+			if b.counts.Of(a) != b.counts.Of(c) {
+				return b.counts.Of(a) > b.counts.Of(c)
+			}
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(c.Name)
+	})
+
+	return entries
+}
+
+// Handle applies a key to the browser.
+//
+// Parameters:
+//   - key: The pressed key.
+//   - char: The character of the key, if key is KeyRune.
+//
+// Returns:
+//   - bool: True if the browser should be closed.
+func (b *Browser) Handle(key Key, char rune) bool {
+	entries := b.Entries()
+	b.Status = ""
+
+	if b.pending != "" {
+		if key == KeyRune && (char == 'y' || char == 'Y') {
+			b.remove()
+		} else {
+			b.Status = "cancelled"
+		}
+		b.pending, b.target = "", nil
+		return false
+	}
+
+	switch key {
+	case KeyUp:
+		b.Cursor--
+	case KeyDown:
+		b.Cursor++
+	case KeyPageUp:
+		b.Cursor -= b.page
+	case KeyPageDown:
+		b.Cursor += b.page
+	case KeyHome:
+		b.Cursor = 0
+	case KeyEnd:
+		b.Cursor = len(entries) - 1
+	case KeyRight, KeyEnter:
+		if b.Cursor < len(entries) && entries[b.Cursor].ItemType == models.ItemTypeDirectory {
+			b.Dir, b.Cursor, b.offset = entries[b.Cursor], 0, 0
+		}
+	case KeyLeft, KeyBackspace:
+		b.up()
+	case KeyRune:
+		switch char {
+		case 'q', 3:
+			return true
+		case 'k':
+			b.Cursor--
+		case 'j':
+			b.Cursor++
+		case 'l':
+			return b.Handle(KeyRight, 0)
+		case 'h':
+			b.up()
+		case 's':
+			b.Sort = (b.Sort + 1) % 3
+		case 'f':
+			b.View = (b.View + 1) % 3
+		case 'r':
+			if err := b.Rescan(); err != nil {
+				b.Status = fmt.Sprintf("rescan failed: %s", err)
+			} else {
+				b.Status = fmt.Sprintf("rescanned %s", b.Dir.Path)
+			}
+		case 'd', 't':
+			if b.Cursor < len(entries) {
+				b.pending, b.target = remove.MethodDelete, entries[b.Cursor]
+				if char == 't' {
+					b.pending = remove.MethodTrash
+				}
+				b.Status = fmt.Sprintf("%s %s [%s]? y/N", b.pending, b.target.Path, unit.NewFromBytes(b.target.Bytes()).RawSizeString())
+			}
+		}
+	}
+
+	b.Cursor = min(b.Cursor, len(b.Entries())-1)
+	b.Cursor = max(b.Cursor, 0)
+
+	return false
+}
+
+// up changes to the parent directory and selects the directory that was left.
+func (b *Browser) up() {
+	lineage := b.Root.Find(b.Dir.Path)
+	if b.Dir == b.Root || len(lineage) < 2 {
+		return
+	}
+
+	left := b.Dir
+	b.Dir, b.Cursor, b.offset = lineage[len(lineage)-2], 0, 0
+	for i, entry := range b.Entries() {
+		if entry == left {
+			b.Cursor = i
+		}
+	}
+}
+
+// remove deletes or trashes the confirmed entry and updates the sizes of its ancestors.
+func (b *Browser) remove() {
+	actions, err := remove.Apply(b.Root, []string{b.target.Path}, b.pending, false)
+	switch {
+	case err != nil:
+		b.Status = fmt.Sprintf("%s failed: %s", b.pending, err)
+	case actions[0].Skipped != "":
+		b.Status = fmt.Sprintf("skipped %s: %s", b.target.Path, actions[0].Skipped)
+	default:
+		verb := "deleted"
+		if b.pending == remove.MethodTrash {
+			verb = "trashed"
+		}
+		b.Status = fmt.Sprintf("%s %s, %s reclaimed", verb, b.target.Path, unit.NewFromBytes(remove.Reclaimed(actions)).RawSizeString())
+	}
+	b.counts = nil
+}
+
+// Rescan reads the current directory from the file system again and updates the sizes of its ancestors.
+//
+// Returns:
+//   - error: An error if the directory cannot be scanned.
+func (b *Browser) Rescan() error {
+	fresh := models.NewItem(b.Dir.Name, b.Dir.Path, models.ItemTypeDirectory)
+	if _, err := utils.Rescan(fresh, b.Dir.Path, nil, nil); err != nil {
+		return err
+	}
+
+	delta := fresh.Bytes() - b.Dir.Bytes()
+	for _, item := range b.Root.Find(b.Dir.Path) {
+		if item != b.Dir {
+			item.Size = unit.NewFromBytes(item.Bytes() + delta)
+		}
+	}
+
+	b.Dir.Children, b.Dir.Size = fresh.Children, unit.NewFromBytes(fresh.Bytes())
+	b.Dir.ModTime, b.Dir.ChangeTime = fresh.ModTime, fresh.ChangeTime
+	b.counts = nil
+
+	return nil
+}
+
+// Render returns the lines of a screen with the given size: a header, the entries of the current
+// directory with their size, share of the directory and a percentage bar, and a help line.
+// The selected entry is highlighted with reverse video.
+//
+// Parameters:
+//   - width: The number of columns of the screen.
+//   - height: The number of rows of the screen.
+//
+// Returns:
+//   - []string: The lines of the screen, at most height.
+func (b *Browser) Render(width, height int) []string {
+	lines := []string{reverse(fit(fmt.Sprintf(" %s [%s]  sort: %s  view: %s",
+		b.Dir.Path, unit.NewFromBytes(b.Dir.Bytes()).RawSizeString(), b.Sort, b.View), width))}
+
+	b.page = max(height-2, 1)
+	if b.Cursor < b.offset {
+		b.offset = b.Cursor
+	} else if b.Cursor >= b.offset+b.page {
+		b.offset = b.Cursor - b.page + 1
+	}
+
+	entries := b.Entries()
+	for i := b.offset; i < len(entries) && i < b.offset+b.page; i++ {
+		line := fit(b.entryLine(entries[i]), width)
+		if i == b.Cursor {
+			line = reverse(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(entries) == 0 {
+		lines = append(lines, fit("  (empty)", width))
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	help := " ↑↓ move  → open  ← back  s sort  f filter  r rescan  d delete  t trash  q quit"
+	if b.Status != "" {
+		help = " " + b.Status
+	}
+
+	lines = append(lines, fit(help, width))
+	if len(lines) > height {
+		lines = lines[:max(height, 0)]
+	}
+
+	return lines
+}
+
+func (b *Browser) entryLine(entry *models.Item) string {
+	share := 0.0
+	if b.Dir.Bytes() > 0 {
+		share = float64(entry.Bytes()) / float64(b.Dir.Bytes())
+	}
+
+	filled := int(share*barWidth + 0.5)
+	bar := strings.Repeat("#", filled) + strings.Repeat(" ", barWidth-filled)
+
+	icon, name := "📄", entry.Name
+	if entry.ItemType == models.ItemTypeDirectory {
+		icon, name = "📁", entry.Name+"/"
+	}
+
+	count := ""
+	if b.Sort == SortByCount {
+		count = fmt.Sprintf("%8d ", b.counts.Of(entry))
+	}
+
+	return fmt.Sprintf(" %10s %5.1f%% [%s] %s%s%s", unit.NewFromBytes(entry.Bytes()).RawSizeString(), share*100, bar, count, icon, name)
+}
+
+// Run shows the scanned tree below root in an interactive full-screen browser until the user quits.
+// It only relies on a plain terminal with ANSI escape sequences, so it also works over SSH.
+//
+// Parameters:
+//   - root: The root item of a scanned tree.
+//
+// Returns:
+//   - error: An error if the terminal cannot be put into raw mode or reading keys fails.
+func Run(root *models.Item) error {
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to enable raw mode: %w", err)
+	}
+	defer restore()
+
+	out := bufio.NewWriter(os.Stdout)
+	// Switch to the alternate screen and hide the cursor, both are restored when leaving.
+	out.WriteString("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		out.WriteString("\x1b[?25h\x1b[?1049l")
+		out.Flush()
+	}()
+
+	keys := make(chan []byte)
+	errs := make(chan error, 1)
+	go readKeys(os.Stdin, keys, errs)
+	resize := resized()
+
+	browser := NewBrowser(root)
+	for {
+		width, height, ok := utils.TerminalSize()
+		if !ok {
+			width, height = 80, 24
+		}
+
+		out.WriteString("\x1b[H\x1b[2J")
+		out.WriteString(strings.Join(browser.Render(width, height), "\r\n"))
+		if err := out.Flush(); err != nil {
+			return err
+		}
+
+		select {
+		case input := <-keys:
+			key, char := ParseKey(input)
+			if browser.Handle(key, char) {
+				return nil
+			}
+		case <-resize:
+		case err := <-errs:
+			return err
+		}
+	}
+}
+
+// ParseKey decodes the bytes of a single key press, including the escape sequences of arrow and navigation keys.
+//
+// Parameters:
+//   - input: The bytes read from the terminal for one key press.
+//
+// Returns:
+//   - Key: The pressed key, KeyRune for printable characters and control characters other than enter and backspace.
+//   - rune: The character if the key is KeyRune.
+func ParseKey(input []byte) (Key, rune) {
+	sequences := map[string]Key{
+		"\x1b[A": KeyUp, "\x1bOA": KeyUp,
+		"\x1b[B": KeyDown, "\x1bOB": KeyDown,
+		"\x1b[C": KeyRight, "\x1bOC": KeyRight,
+		"\x1b[D": KeyLeft, "\x1bOD": KeyLeft,
+		"\x1b[5~": KeyPageUp, "\x1b[6~": KeyPageDown,
+		"\x1b[H": KeyHome, "\x1bOH": KeyHome, "\x1b[1~": KeyHome,
+		"\x1b[F": KeyEnd, "\x1bOF": KeyEnd, "\x1b[4~": KeyEnd,
+	}
+	if key, ok := sequences[string(input)]; ok {
+		return key, 0
+	}
+
+	switch {
+	case len(input) == 0:
+		return KeyUnknown, 0
+	case input[0] == '\r' || input[0] == '\n':
+		return KeyEnter, 0
+	case input[0] == 0x7f || input[0] == '\b':
+		return KeyBackspace, 0
+	case input[0] == 0x1b:
+		return KeyUnknown, 0
+	}
+
+	char, _ := utf8.DecodeRune(input)
+
+	return KeyRune, char
+}
+
+// readKeys reads key presses from r. A single read returns the bytes of one key press in a terminal in raw mode.
+func readKeys(r io.Reader, keys chan<- []byte, errs chan<- error) {
+	buf := make([]byte, 32)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		keys <- append([]byte(nil), buf[:n]...)
+	}
+}
+
+// fit cuts line to width columns. Emoji are counted as two columns.
+func fit(line string, width int) string {
+	columns := 0
+	for i, char := range line {
+		charWidth := 1
+		if char >= 0x1F300 {
+			charWidth = 2
+		}
+		if columns+charWidth > width {
+			return line[:i]
+		}
+		columns += charWidth
+	}
+
+	return line + strings.Repeat(" ", width-columns)
+}
+
+func reverse(line string) string {
+	return "\x1b[7m" + line + "\x1b[0m"
+}
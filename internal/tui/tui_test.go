@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTree builds root (3000) with the files b (1000) and the directory a (2000),
+// which contains the files x (1500) and y (500).
+func testTree() *models.Item {
+	root := models.NewItemWithSize("root", "root", models.ItemTypeDirectory, unit.NewFromBytes(3000))
+	root.Root = true
+	dir := models.NewItemWithSize("a", filepath.Join("root", "a"), models.ItemTypeDirectory, unit.NewFromBytes(2000))
+	dir.Children = append(dir.Children,
+		models.NewItemWithSize("x", filepath.Join("root", "a", "x"), models.ItemTypeFile, unit.NewFromBytes(1500)),
+		models.NewItemWithSize("y", filepath.Join("root", "a", "y"), models.ItemTypeFile, unit.NewFromBytes(500)))
+	root.Children = append(root.Children,
+		models.NewItemWithSize("b", filepath.Join("root", "b"), models.ItemTypeFile, unit.NewFromBytes(1000)), dir)
+
+	return root
+}
+
+func names(items []*models.Item) []string {
+	result := []string{}
+	for _, item := range items {
+		result = append(result, item.Name)
+	}
+	return result
+}
+
+func TestBrowser_Entries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sort SortMode
+		view ViewMode
+		want []string
+	}{
+		{name: "By size", sort: SortBySize, view: ViewAll, want: []string{"a", "b"}},
+		{name: "By name", sort: SortByName, view: ViewAll, want: []string{"a", "b"}},
+		{name: "By count", sort: SortByCount, view: ViewAll, want: []string{"a", "b"}},
+		{name: "Directories only", sort: SortBySize, view: ViewDirectories, want: []string{"a"}},
+		{name: "Files only", sort: SortBySize, view: ViewFiles, want: []string{"b"}},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			browser := NewBrowser(testTree())
+			browser.Sort, browser.View = tt.sort, tt.view
+			assert.Equal(t, tt.want, names(browser.Entries()))
+		})
+	}
+}
+
+func TestBrowser_Handle(t *testing.T) {
+	t.Parallel()
+
+	browser := NewBrowser(testTree())
+
+	browser.Handle(KeyUp, 0)
+	assert.Equal(t, 0, browser.Cursor, "Cursor stays on the first entry")
+
+	browser.Handle(KeyEnter, 0)
+	assert.Equal(t, "a", browser.Dir.Name, "Enter opens the selected directory")
+	assert.Equal(t, []string{"x", "y"}, names(browser.Entries()))
+
+	browser.Handle(KeyEnd, 0)
+	assert.Equal(t, 1, browser.Cursor)
+	browser.Handle(KeyRight, 0)
+	assert.Equal(t, "a", browser.Dir.Name, "Files cannot be opened")
+
+	browser.Handle(KeyLeft, 0)
+	assert.Equal(t, "root", browser.Dir.Name)
+	assert.Equal(t, 0, browser.Cursor, "The directory that was left is selected")
+
+	browser.Handle(KeyRune, 's')
+	assert.Equal(t, SortByName, browser.Sort)
+	browser.Handle(KeyRune, 'f')
+	assert.Equal(t, ViewDirectories, browser.View)
+	browser.Handle(KeyDown, 0)
+	assert.Equal(t, 0, browser.Cursor, "Cursor is limited to the shown entries")
+
+	assert.False(t, browser.Handle(KeyBackspace, 0))
+	assert.True(t, browser.Handle(KeyRune, 'q'))
+	assert.True(t, browser.Handle(KeyRune, 3), "Ctrl-C quits")
+}
+
+func TestBrowser_Rescan(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(base, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "file"), make([]byte, 10), 0o644))
+
+	root := models.NewItemWithSize("base", base, models.ItemTypeDirectory, unit.NewFromBytes(10))
+	root.Root = true
+	dir := models.NewItemWithSize("dir", filepath.Join(base, "dir"), models.ItemTypeDirectory, unit.NewFromBytes(10))
+	dir.Children = append(dir.Children, models.NewItemWithSize("file", filepath.Join(base, "dir", "file"), models.ItemTypeFile, unit.NewFromBytes(10)))
+	root.Children = append(root.Children, dir)
+
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "new"), make([]byte, 5), 0o644))
+
+	browser := NewBrowser(root)
+	browser.Handle(KeyEnter, 0)
+	browser.Handle(KeyRune, 'r')
+
+	assert.Contains(t, browser.Status, "rescanned")
+	assert.Equal(t, int64(15), dir.Size.Size)
+	assert.Equal(t, int64(15), root.Size.Size, "Ancestors are updated")
+	assert.ElementsMatch(t, []string{"file", "new"}, names(dir.Children))
+}
+
+func TestBrowser_Remove(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(base, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "big"), make([]byte, 10), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "dir", "small"), make([]byte, 5), 0o644))
+
+	root := models.NewItemWithSize("base", base, models.ItemTypeDirectory, unit.NewFromBytes(15))
+	root.Root = true
+	dir := models.NewItemWithSize("dir", filepath.Join(base, "dir"), models.ItemTypeDirectory, unit.NewFromBytes(15))
+	dir.Children = append(dir.Children,
+		models.NewItemWithSize("big", filepath.Join(base, "dir", "big"), models.ItemTypeFile, unit.NewFromBytes(10)),
+		models.NewItemWithSize("small", filepath.Join(base, "dir", "small"), models.ItemTypeFile, unit.NewFromBytes(5)))
+	root.Children = append(root.Children, dir)
+
+	browser := NewBrowser(root)
+	browser.Handle(KeyEnter, 0)
+
+	browser.Handle(KeyRune, 'd')
+	assert.Contains(t, browser.Status, "delete "+filepath.Join(base, "dir", "big")+" [10.00B]? y/N")
+	browser.Handle(KeyRune, 'n')
+	assert.Equal(t, "cancelled", browser.Status)
+	assert.FileExists(t, filepath.Join(base, "dir", "big"))
+	assert.Equal(t, int64(15), root.Size.Size)
+
+	browser.Handle(KeyRune, 'd')
+	browser.Handle(KeyRune, 'y')
+	assert.Contains(t, browser.Status, "deleted "+filepath.Join(base, "dir", "big")+", 10.00B reclaimed")
+	assert.NoFileExists(t, filepath.Join(base, "dir", "big"))
+	assert.Equal(t, []string{"small"}, names(browser.Entries()))
+	assert.Equal(t, int64(5), dir.Size.Size)
+	assert.Equal(t, int64(5), root.Size.Size, "Ancestors are updated")
+
+	browser.Handle(KeyRune, 'd')
+	browser.Handle(KeyDown, 0)
+	assert.Equal(t, "cancelled", browser.Status, "Any other key cancels")
+	assert.FileExists(t, filepath.Join(base, "dir", "small"))
+}
+
+func TestBrowser_Render(t *testing.T) {
+	t.Parallel()
+
+	browser := NewBrowser(testTree())
+	lines := browser.Render(80, 6)
+
+	require.Len(t, lines, 6)
+	assert.Contains(t, lines[0], "root [2.93KB]  sort: size  view: all")
+	assert.Contains(t, lines[1], "\x1b[7m", "The selected entry is highlighted")
+	assert.Contains(t, lines[1], " 66.7% [#############       ] 📁a/")
+	assert.Contains(t, lines[2], " 33.3% [#######             ] 📄b")
+	assert.Equal(t, "", lines[3])
+	assert.Contains(t, lines[5], "q quit")
+
+	assert.Len(t, browser.Render(80, 2), 2, "Lines are limited to the height")
+}
+
+func TestParseKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		key   Key
+		char  rune
+	}{
+		{input: "\x1b[A", key: KeyUp},
+		{input: "\x1bOB", key: KeyDown},
+		{input: "\x1b[C", key: KeyRight},
+		{input: "\x1b[D", key: KeyLeft},
+		{input: "\x1b[5~", key: KeyPageUp},
+		{input: "\x1b[6~", key: KeyPageDown},
+		{input: "\r", key: KeyEnter},
+		{input: "\x7f", key: KeyBackspace},
+		{input: "\x1b", key: KeyUnknown},
+		{input: "s", key: KeyRune, char: 's'},
+		{input: "ä", key: KeyRune, char: 'ä'},
+	}
+
+	for _, tt_ := range tests {
+		tt := tt_
+		t.Run(strings.ReplaceAll(tt.input, "\x1b", "ESC"), func(t *testing.T) {
+			t.Parallel()
+
+			key, char := ParseKey([]byte(tt.input))
+			assert.Equal(t, tt.key, key)
+			assert.Equal(t, tt.char, char)
+		})
+	}
+}
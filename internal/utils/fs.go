@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/StevenCyb/MemSpace/internal/models"
 	"github.com/StevenCyb/MemSpace/internal/unit"
@@ -11,6 +12,13 @@ import (
 
 var ErrRecursionEnd = fmt.Errorf("recursion end")
 
+// FileID identifies a file on a system by its device and inode number.
+// Hardlinks of the same file share the same FileID.
+type FileID struct {
+	Device uint64
+	Inode  uint64
+}
+
 // GetName returns the base name of the given file path.
 // It extracts the last element of the path, which is typically
 // the file or directory name.
@@ -38,18 +46,23 @@ func GetName(path string) string {
 //   - *unit.Size: The size of the file in bytes wrapped in a unit.Size object.
 //   - error: An error if the file cannot be opened or its metadata cannot be retrieved.
 func FileSize(path string) (*unit.Size, error) {
-	file, err := os.Open(path)
+	info, err := fileInfo(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	stat, err := file.Stat()
+	return unit.NewFromBytes(info.Size()), nil
+}
+
+// fileInfo opens the file at path and returns its metadata, so unreadable files fail like in FileSize.
+func fileInfo(path string) (os.FileInfo, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	return unit.NewFromBytes(stat.Size()), nil
+	return file.Stat()
 }
 
 // WalkAndCollect traverses the directory tree starting from the specified path,
@@ -70,8 +83,62 @@ func FileSize(path string) (*unit.Size, error) {
 //   - If the entry is a file, it calculates its size and adds it to the parent's children.
 //
 // The parent *models.Item is updated with its children and their respective sizes.
+// Files and directories additionally get their modification and change time recorded.
 // The total size of all files and directories is returned.
 func WalkAndCollect(parent *models.Item, path string, currentDepth int) (*unit.Size, error) {
+	return walk(parent, path, nil, nil, currentDepth)
+}
+
+// RescanStats counts how directories were handled by Rescan.
+//
+// Fields:
+//   - Reused: The number of directories whose listing was taken from the cache.
+//   - Rescanned: The number of directories that were read from the file system.
+type RescanStats struct {
+	Reused    int
+	Rescanned int
+}
+
+// Rescan works like WalkAndCollect but takes a previously collected tree of the same path
+// into account. A directory whose modification and change time equal the cached ones has
+// not had entries added, removed or renamed, so its entries are taken from the cache instead
+// of reading the directory. The cached files are still stated, as files modified in place
+// (e.g., appended to) do not update the times of their directory. Subdirectories are still
+// visited, as changes inside them do not update the times of their parent either.
+//
+// Parameters:
+//   - parent: A pointer to a models.Item representing the directory at path.
+//   - path: The file system path to start traversing from.
+//   - cached: The previously collected item for path, may be nil.
+//   - stats: Counts reused and rescanned directories, may be nil.
+//
+// Returns:
+//   - *unit.Size: The total size of all files and directories under the given path.
+//   - error: An error if any issues occur during directory traversal or file size calculation.
+func Rescan(parent *models.Item, path string, cached *models.Item, stats *RescanStats) (*unit.Size, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	parent.ModTime, parent.ChangeTime = info.ModTime(), ChangeTime(info)
+
+	return walk(parent, path, cached, stats, 0)
+}
+
+func walk(parent *models.Item, path string, cached *models.Item, stats *RescanStats, currentDepth int) (*unit.Size, error) {
+	if cached != nil && cached.ItemType == models.ItemTypeDirectory && !parent.ModTime.IsZero() &&
+		parent.ModTime.Equal(cached.ModTime) && parent.ChangeTime.Equal(cached.ChangeTime) {
+		if stats != nil {
+			stats.Reused++
+		}
+
+		return reuse(parent, path, cached, stats, currentDepth)
+	}
+
+	if stats != nil {
+		stats.Rescanned++
+	}
+
 	totalSize := unit.NewFromBytes(0)
 
 	entries, err := os.ReadDir(path)
@@ -79,12 +146,30 @@ func WalkAndCollect(parent *models.Item, path string, currentDepth int) (*unit.S
 		return nil, err
 	}
 
+	cachedDirs := map[string]*models.Item{}
+	if cached != nil {
+		for _, child := range cached.Children {
+			if child.ItemType == models.ItemTypeDirectory {
+				cachedDirs[child.Name] = child
+			}
+		}
+	}
+
 	for _, entry := range entries {
 		if entry.IsDir() {
 			relativePath := filepath.Join(path, entry.Name())
 			newParent := models.NewItem(entry.Name(), relativePath, models.ItemTypeDirectory)
 
-			size, err := WalkAndCollect(newParent, filepath.Join(path, entry.Name()), currentDepth+1)
+			info, err := entry.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			newParent.ModTime, newParent.ChangeTime = info.ModTime(), ChangeTime(info)
+
+			size, err := walk(newParent, relativePath, cachedDirs[entry.Name()], stats, currentDepth+1)
 			if err != nil {
 				return nil, err
 			}
@@ -93,7 +178,8 @@ func WalkAndCollect(parent *models.Item, path string, currentDepth int) (*unit.S
 
 			totalSize.Add(size)
 		} else {
-			size, err := FileSize(filepath.Join(path, entry.Name()))
+			relativePath := filepath.Join(path, entry.Name())
+			info, err := fileInfo(relativePath)
 			if err != nil {
 				if os.IsNotExist(err) {
 					continue
@@ -101,9 +187,61 @@ func WalkAndCollect(parent *models.Item, path string, currentDepth int) (*unit.S
 				return nil, err
 			}
 
+			size := unit.NewFromBytes(info.Size())
 			totalSize.Add(size)
-			relativePath := filepath.Join(path, entry.Name())
-			parent.Children = append(parent.Children, models.NewItemWithSize(entry.Name(), relativePath, models.ItemTypeFile, size))
+			file := models.NewItemWithSize(entry.Name(), relativePath, models.ItemTypeFile, size)
+			file.ModTime, file.ChangeTime = info.ModTime(), ChangeTime(info)
+			parent.Children = append(parent.Children, file)
+		}
+	}
+
+	parent.Size = totalSize
+
+	return totalSize, nil
+}
+
+// reuse populates parent from the cached listing of the unchanged directory at path,
+// stating the listed files to pick up their current size.
+func reuse(parent *models.Item, path string, cached *models.Item, stats *RescanStats, currentDepth int) (*unit.Size, error) {
+	totalSize := unit.NewFromBytes(0)
+
+	for _, child := range cached.Children {
+		relativePath := filepath.Join(path, child.Name)
+
+		if child.ItemType == models.ItemTypeDirectory {
+			info, err := os.Lstat(relativePath)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+
+			newParent := models.NewItem(child.Name, relativePath, models.ItemTypeDirectory)
+			newParent.ModTime, newParent.ChangeTime = info.ModTime(), ChangeTime(info)
+
+			size, err := walk(newParent, relativePath, child, stats, currentDepth+1)
+			if err != nil {
+				return nil, err
+			}
+			newParent.Size = size
+			parent.Children = append(parent.Children, newParent)
+
+			totalSize.Add(size)
+		} else {
+			info, err := fileInfo(relativePath)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+
+			size := unit.NewFromBytes(info.Size())
+			totalSize.Add(size)
+			file := models.NewItemWithSize(child.Name, relativePath, models.ItemTypeFile, size)
+			file.ModTime, file.ChangeTime = info.ModTime(), ChangeTime(info)
+			parent.Children = append(parent.Children, file)
 		}
 	}
 
@@ -111,3 +249,86 @@ func WalkAndCollect(parent *models.Item, path string, currentDepth int) (*unit.S
 
 	return totalSize, nil
 }
+
+// Entry describes a file or a completely traversed directory reported by Stream.
+//
+// Fields:
+//   - Name: The name of the entry.
+//   - Path: The path of the entry.
+//   - ItemType: The type of the entry (e.g., file, directory).
+//   - Size: The size of a file or the total size of everything below a directory.
+//   - Depth: The depth below the path passed to Stream, which itself has depth 0.
+//   - ModTime: The modification time of the entry, zero for the path passed to Stream.
+//   - ChangeTime: The inode change time (ctime) of the entry, zero for the path passed to Stream.
+type Entry struct {
+	Name       string
+	Path       string
+	ItemType   models.ItemType
+	Size       *unit.Size
+	Depth      int
+	ModTime    time.Time
+	ChangeTime time.Time
+}
+
+// Stream traverses the directory tree like WalkAndCollect, but instead of building a tree it
+// passes every entry to fn as soon as it is known. Files are reported when they are reached,
+// directories after all their content was reported, so their size is complete. The directory
+// at path itself is reported last. Only the directories on the current path are held in memory.
+//
+// Parameters:
+//   - path: The file system path to start traversing from.
+//   - fn: Called for every entry; returning an error stops the traversal.
+//
+// Returns:
+//   - *unit.Size: The total size of all files and directories under the given path.
+//   - error: An error if any issues occur during traversal or fn returns one.
+func Stream(path string, fn func(Entry) error) (*unit.Size, error) {
+	size, err := stream(path, 1, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return size, fn(Entry{Name: GetName(path), Path: path, ItemType: models.ItemTypeDirectory, Size: size})
+}
+
+func stream(path string, depth int, fn func(Entry) error) (*unit.Size, error) {
+	totalSize := unit.NewFromBytes(0)
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		relativePath := filepath.Join(path, entry.Name())
+
+		var info os.FileInfo
+		if entry.IsDir() {
+			info, err = entry.Info()
+		} else {
+			info, err = fileInfo(relativePath)
+		}
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		size := unit.NewFromBytes(info.Size())
+		itemType := models.ItemTypeFile
+		if entry.IsDir() {
+			itemType = models.ItemTypeDirectory
+			if size, err = stream(relativePath, depth+1, fn); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := fn(Entry{Name: entry.Name(), Path: relativePath, ItemType: itemType, Size: size, Depth: depth,
+			ModTime: info.ModTime(), ChangeTime: ChangeTime(info)}); err != nil {
+			return nil, err
+		}
+		totalSize.Add(size)
+	}
+
+	return totalSize, nil
+}
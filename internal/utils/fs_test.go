@@ -2,7 +2,9 @@ package utils
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/StevenCyb/MemSpace/internal/models"
 	"github.com/StevenCyb/MemSpace/internal/unit"
@@ -141,9 +143,108 @@ func TestWalkAndCollect(t *testing.T) {
 			},
 		},
 	}
+	setTimes(t, expected.Children[0], "test_data/a")
+	setTimes(t, expected.Children[1], "test_data/b")
+	setTimes(t, expected.Children[2], "test_data/c")
+	setTimes(t, expected.Children[2].Children[0], "test_data/c/c.txt")
+	setTimes(t, expected.Children[2].Children[1], "test_data/c/d")
+	setTimes(t, expected.Children[2].Children[1].Children[0], "test_data/c/d/d.dat")
+
 	parent := models.NewItem("./test_data", ".", models.ItemTypeDirectory)
 	totalSize, err := WalkAndCollect(parent, "./test_data", 0)
 	assert.NoError(t, err, "Unexpected error occurred")
 	assert.Equal(t, &unit.Size{Size: 10}, totalSize)
 	assert.Equal(t, expected, parent)
 }
+
+func TestRescan(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	for path, content := range map[string]string{"a": "aa", "static/s": "sss", "static/deep/d": "d", "busy/b": "b"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(base, path)), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(base, path), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	cached := models.NewItem("base", base, models.ItemTypeDirectory)
+	stats := &RescanStats{}
+	_, err := Rescan(cached, base, nil, stats)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, &RescanStats{Rescanned: 4}, stats)
+
+	// Append to a file in place, which leaves the times of "static" unchanged.
+	file, err := os.OpenFile(filepath.Join(base, "static/s"), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	if _, err := file.WriteString("sssss"); err != nil {
+		t.Fatalf("Failed to append to file: %v", err)
+	}
+	file.Close()
+	if err := os.WriteFile(filepath.Join(base, "busy/new"), []byte("new"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	// Ensure the change is visible even on file systems with coarse timestamps.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(base, "busy"), later, later); err != nil {
+		t.Fatalf("Failed to change times: %v", err)
+	}
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	stats = &RescanStats{}
+	totalSize, err := Rescan(root, base, cached, stats)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, &RescanStats{Reused: 3, Rescanned: 1}, stats)
+	assert.Equal(t, &unit.Size{Size: 2 + 1 + 3 + 1 + 8}, totalSize)
+	assert.Len(t, root.Children[1].Children, 2, "Expected the changed directory to be rescanned")
+	assert.Equal(t, &unit.Size{Size: 8}, root.Children[2].Children[1].Size, "Expected the appended file size to be picked up")
+	assert.Equal(t, &unit.Size{Size: 1 + 8}, root.Children[2].Size, "Expected the reused directory size to include the append")
+	assert.False(t, root.Children[1].Children[1].ModTime.IsZero(), "Expected the times of rescanned files to be recorded")
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	expected := []Entry{
+		{Name: "a", Path: "test_data/a", ItemType: models.ItemTypeFile, Size: &unit.Size{Size: 2}, Depth: 1},
+		{Name: "b", Path: "test_data/b", ItemType: models.ItemTypeFile, Size: &unit.Size{Size: 3}, Depth: 1},
+		{Name: "c.txt", Path: "test_data/c/c.txt", ItemType: models.ItemTypeFile, Size: &unit.Size{Size: 2}, Depth: 2},
+		{Name: "d.dat", Path: "test_data/c/d/d.dat", ItemType: models.ItemTypeFile, Size: &unit.Size{Size: 3}, Depth: 3},
+		{Name: "d", Path: "test_data/c/d", ItemType: models.ItemTypeDirectory, Size: &unit.Size{Size: 3}, Depth: 2},
+		{Name: "c", Path: "test_data/c", ItemType: models.ItemTypeDirectory, Size: &unit.Size{Size: 5}, Depth: 1},
+		{Name: "test_data", Path: "./test_data", ItemType: models.ItemTypeDirectory, Size: &unit.Size{Size: 10}, Depth: 0},
+	}
+	for i := range expected[:len(expected)-1] {
+		info, err := os.Stat(expected[i].Path)
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", expected[i].Path, err)
+		}
+		expected[i].ModTime, expected[i].ChangeTime = info.ModTime(), ChangeTime(info)
+	}
+
+	entries := []Entry{}
+	totalSize, err := Stream("./test_data", func(entry Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, &unit.Size{Size: 10}, totalSize)
+	assert.Equal(t, expected, entries)
+
+	_, err = Stream("./test_data", func(Entry) error { return ErrRecursionEnd })
+	assert.ErrorIs(t, err, ErrRecursionEnd, "Expected the error of the callback")
+}
+
+func setTimes(t *testing.T, item *models.Item, path string) {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat %s: %v", path, err)
+	}
+	item.ModTime, item.ChangeTime = info.ModTime(), ChangeTime(info)
+}
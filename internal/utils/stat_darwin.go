@@ -0,0 +1,42 @@
+//go:build darwin
+
+package utils
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// ChangeTime returns the inode change time (ctime) of the given file info.
+// If the platform specific information is not available, the modification time is returned.
+func ChangeTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime()
+	}
+
+	return time.Unix(stat.Ctimespec.Sec, stat.Ctimespec.Nsec)
+}
+
+// GetFileID returns the device and inode number of the given file info.
+// The second return value is false if the platform specific information is not available.
+func GetFileID(info os.FileInfo) (FileID, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return FileID{}, false
+	}
+
+	return FileID{Device: uint64(stat.Dev), Inode: stat.Ino}, true
+}
+
+// GetOwner returns the user and group id owning the given file info.
+// The third return value is false if the platform specific information is not available.
+func GetOwner(info os.FileInfo) (int, int, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return int(stat.Uid), int(stat.Gid), true
+}
@@ -0,0 +1,24 @@
+//go:build !linux && !darwin
+
+package utils
+
+import (
+	"os"
+	"time"
+)
+
+// ChangeTime returns the modification time of the given file info, as the inode
+// change time is not available on this platform.
+func ChangeTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
+
+// GetFileID is not supported on this platform and always returns false.
+func GetFileID(_ os.FileInfo) (FileID, bool) {
+	return FileID{}, false
+}
+
+// GetOwner is not supported on this platform and always returns false.
+func GetOwner(_ os.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package utils
+
+// TerminalSize is not supported on this platform and always reports that stdout is not a terminal.
+func TerminalSize() (width, height int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package utils
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// TerminalSize returns the number of columns and rows of the terminal attached to stdout.
+// ok is false if stdout is not a terminal.
+func TerminalSize() (width, height int, ok bool) {
+	size, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || size.Col == 0 || size.Row == 0 {
+		return 0, 0, false
+	}
+
+	return int(size.Col), int(size.Row), true
+}
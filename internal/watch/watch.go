@@ -0,0 +1,175 @@
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/unit"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+)
+
+var ErrUnsupported = errors.New("watch mode is not supported on this platform")
+
+// Stats describes how the directories of a watched tree are observed.
+//
+// Fields:
+//   - Watched: The number of directories observed through file system events.
+//   - Polled: The number of directories that could not be watched (e.g., because the
+//     watch limit was reached) and are re-read every interval instead.
+type Stats struct {
+	Watched int
+	Polled  int
+}
+
+// tree keeps the sizes of a scanned models.Item tree consistent while entries
+// are added, modified and removed.
+type tree struct {
+	root *models.Item
+}
+
+// sync brings the child name of the directory dir in line with the file system.
+// Sizes of dir and all its ancestors are updated by the difference.
+// Nothing is done if dir is no longer part of the tree.
+//
+// Returns:
+//   - added: All directories of a newly added subtree.
+//   - removed: All directories of a removed subtree.
+//   - error: An error if the entry cannot be inspected or scanned.
+func (t *tree) sync(dir *models.Item, name string) (added, removed []*models.Item, err error) {
+	if lineage := t.root.Find(dir.Path); len(lineage) == 0 || lineage[len(lineage)-1] != dir {
+		return nil, nil, nil
+	}
+
+	path := filepath.Join(dir.Path, name)
+
+	index := -1
+	for i, child := range dir.Children {
+		if child.Name == name {
+			index = i
+			break
+		}
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	if index >= 0 {
+		child := dir.Children[index]
+		if err == nil && info.IsDir() == (child.ItemType == models.ItemTypeDirectory) {
+			if child.ItemType == models.ItemTypeFile {
+				size, err := utils.FileSize(path)
+				if err != nil && !os.IsNotExist(err) {
+					return nil, nil, err
+				} else if err == nil {
+					t.grow(dir, size.Size-child.Size.Size)
+					child.Size = size
+				}
+			}
+
+			return nil, nil, nil
+		}
+
+		dir.Children = append(dir.Children[:index], dir.Children[index+1:]...)
+		t.grow(dir, -child.Size.Size)
+		removed = directories(child)
+	}
+
+	if os.IsNotExist(err) {
+		return nil, removed, nil
+	}
+
+	var child *models.Item
+	if info.IsDir() {
+		child = models.NewItem(name, path, models.ItemTypeDirectory)
+		child.ModTime, child.ChangeTime = info.ModTime(), utils.ChangeTime(info)
+		if _, err := utils.WalkAndCollect(child, path, 0); err != nil {
+			if os.IsNotExist(err) {
+				return nil, removed, nil
+			}
+			return nil, removed, err
+		}
+		added = directories(child)
+	} else {
+		size, err := utils.FileSize(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, removed, nil
+			}
+			return nil, removed, err
+		}
+		child = models.NewItemWithSize(name, path, models.ItemTypeFile, size)
+		child.ModTime, child.ChangeTime = info.ModTime(), utils.ChangeTime(info)
+	}
+
+	dir.Children = append(dir.Children, child)
+	t.grow(dir, child.Size.Size)
+
+	return added, removed, nil
+}
+
+// poll re-reads the directory dir and syncs every entry that exists on the
+// file system or in the tree.
+func (t *tree) poll(dir *models.Item) (added, removed []*models.Item, err error) {
+	entries, err := os.ReadDir(dir.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(entries)+len(dir.Children))
+	seen := map[string]struct{}{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+		seen[entry.Name()] = struct{}{}
+	}
+	for _, child := range dir.Children {
+		if _, ok := seen[child.Name]; !ok {
+			names = append(names, child.Name)
+		}
+	}
+
+	for _, name := range names {
+		a, r, err := t.sync(dir, name)
+		if err != nil {
+			return added, removed, err
+		}
+		added = append(added, a...)
+		removed = append(removed, r...)
+	}
+
+	return added, removed, nil
+}
+
+// grow adds delta bytes to the directory dir and all its ancestors.
+func (t *tree) grow(dir *models.Item, delta int64) {
+	if delta == 0 {
+		return
+	}
+
+	for _, item := range t.root.Find(dir.Path) {
+		if item.Size == nil {
+			item.Size = unit.NewFromBytes(0)
+		}
+		item.Size.Add(&unit.Size{Size: delta})
+	}
+}
+
+// directories returns item and all directories below it, if item is a directory.
+func directories(item *models.Item) []*models.Item {
+	if item.ItemType != models.ItemTypeDirectory {
+		return nil
+	}
+
+	dirs := []*models.Item{item}
+	for _, child := range item.Children {
+		dirs = append(dirs, directories(child)...)
+	}
+
+	return dirs
+}
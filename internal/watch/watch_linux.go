@@ -0,0 +1,216 @@
+//go:build linux
+
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+
+	"golang.org/x/sys/unix"
+)
+
+const mask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ONLYDIR | unix.IN_DONT_FOLLOW
+
+type event struct {
+	wd       int
+	mask     uint32
+	name     string
+	overflow bool
+}
+
+type entry struct {
+	dir  *models.Item
+	name string
+}
+
+type watcher struct {
+	tree
+	fd       int
+	wds      map[int]*models.Item
+	watched  map[*models.Item]int
+	polled   map[*models.Item]struct{}
+	pending  map[entry]struct{}
+	overflow bool
+}
+
+// Run observes the scanned tree below root through inotify and keeps the sizes of its items
+// up to date. Events are collected and applied every interval, after which redraw is called
+// if anything changed. If a directory cannot be watched, for example because the inotify
+// watch limit (fs.inotify.max_user_watches) is reached, it is re-read every interval instead.
+//
+// Parameters:
+//   - root: The root item of a tree collected by utils.WalkAndCollect or utils.Rescan.
+//   - interval: The interval in which changes are applied and the output is redrawn.
+//   - redraw: Called with the current watch statistics once initially and after each change.
+//
+// Returns:
+//   - error: An error if inotify cannot be initialized or reading events fails.
+//
+// Run blocks until an error occurs.
+func Run(root *models.Item, interval time.Duration, redraw func(Stats)) error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC)
+	if err != nil {
+		return fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+	defer unix.Close(fd)
+
+	w := &watcher{
+		tree:    tree{root: root},
+		fd:      fd,
+		wds:     map[int]*models.Item{},
+		watched: map[*models.Item]int{},
+		polled:  map[*models.Item]struct{}{},
+		pending: map[entry]struct{}{},
+	}
+	w.add(directories(root))
+
+	events := make(chan event, 1024)
+	errs := make(chan error, 1)
+	go w.read(events, errs)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	redraw(w.stats())
+	for {
+		select {
+		case ev := <-events:
+			w.handle(ev)
+		case err := <-errs:
+			return err
+		case <-ticker.C:
+			changed, err := w.apply()
+			if err != nil {
+				return err
+			}
+			if changed {
+				redraw(w.stats())
+			}
+		}
+	}
+}
+
+func (w *watcher) stats() Stats {
+	return Stats{Watched: len(w.watched), Polled: len(w.polled)}
+}
+
+func (w *watcher) add(dirs []*models.Item) {
+	for _, dir := range dirs {
+		wd, err := unix.InotifyAddWatch(w.fd, dir.Path, mask)
+		if err != nil {
+			if !errors.Is(err, unix.ENOENT) {
+				w.polled[dir] = struct{}{}
+			}
+			continue
+		}
+
+		w.wds[wd] = dir
+		w.watched[dir] = wd
+	}
+}
+
+func (w *watcher) remove(dirs []*models.Item) {
+	for _, dir := range dirs {
+		if wd, ok := w.watched[dir]; ok {
+			_, _ = unix.InotifyRmWatch(w.fd, uint32(wd))
+			delete(w.wds, wd)
+			delete(w.watched, dir)
+		}
+		delete(w.polled, dir)
+	}
+}
+
+func (w *watcher) handle(ev event) {
+	if ev.overflow {
+		w.overflow = true
+		return
+	}
+
+	if ev.mask&unix.IN_IGNORED != 0 {
+		if dir, ok := w.wds[ev.wd]; ok {
+			delete(w.watched, dir)
+			delete(w.wds, ev.wd)
+		}
+		return
+	}
+
+	if dir, ok := w.wds[ev.wd]; ok && ev.name != "" {
+		w.pending[entry{dir: dir, name: ev.name}] = struct{}{}
+	}
+}
+
+// apply syncs all pending entries and polls unwatched directories. After a queue
+// overflow, when events may have been lost, all directories are polled.
+func (w *watcher) apply() (bool, error) {
+	changed := len(w.pending) > 0 || w.overflow
+	size := w.root.Size.Size
+
+	var added, removed []*models.Item
+	for e := range w.pending {
+		a, r, err := w.sync(e.dir, e.name)
+		if err != nil {
+			return changed, err
+		}
+		added, removed = append(added, a...), append(removed, r...)
+	}
+	clear(w.pending)
+
+	dirs := make([]*models.Item, 0, len(w.polled))
+	for dir := range w.polled {
+		dirs = append(dirs, dir)
+	}
+	if w.overflow {
+		w.overflow = false
+		for dir := range w.watched {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		a, r, err := w.poll(dir)
+		if err != nil {
+			return changed, err
+		}
+		added, removed = append(added, a...), append(removed, r...)
+	}
+
+	w.remove(removed)
+	w.add(added)
+
+	return changed || size != w.root.Size.Size || len(added) > 0 || len(removed) > 0, nil
+}
+
+func (w *watcher) read(events chan<- event, errs chan<- error) {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := unix.Read(w.fd, buf)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			errs <- fmt.Errorf("failed to read inotify events: %w", err)
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			nameEnd := nameStart + int(raw.Len)
+
+			events <- event{
+				wd:       int(raw.Wd),
+				mask:     raw.Mask,
+				name:     string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00")),
+				overflow: raw.Mask&unix.IN_Q_OVERFLOW != 0,
+			}
+
+			offset = nameEnd
+		}
+	}
+}
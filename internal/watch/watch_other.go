@@ -0,0 +1,14 @@
+//go:build !linux
+
+package watch
+
+import (
+	"time"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+)
+
+// Run is not supported on this platform, as it relies on inotify, and always returns ErrUnsupported.
+func Run(_ *models.Item, _ time.Duration, _ func(Stats)) error {
+	return ErrUnsupported
+}
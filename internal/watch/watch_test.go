@@ -0,0 +1,68 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Poll(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	write(t, filepath.Join(base, "logs", "app.log"), "log")
+	write(t, filepath.Join(base, "old"), "old")
+
+	root := models.NewItem("base", base, models.ItemTypeDirectory)
+	root.Root = true
+	_, err := utils.WalkAndCollect(root, base, 0)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, int64(6), root.Size.Size)
+
+	write(t, filepath.Join(base, "logs", "app.log"), "longer log")
+	write(t, filepath.Join(base, "new", "nested", "file"), "12345")
+	if err := os.Remove(filepath.Join(base, "old")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	tr := &tree{root: root}
+	added, removed, err := tr.poll(root)
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Empty(t, removed)
+	assert.Len(t, added, 2, "Expected the new directory and its subdirectory")
+
+	added, removed, err = tr.poll(root.Children[0])
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	assert.Equal(t, int64(15), root.Size.Size)
+	assert.Equal(t, int64(10), root.Children[0].Size.Size)
+	assert.Len(t, root.Children, 2, "Expected the removed file to be dropped")
+
+	if err := os.RemoveAll(filepath.Join(base, "new")); err != nil {
+		t.Fatalf("Failed to remove directory: %v", err)
+	}
+
+	added, removed, err = tr.sync(root, "new")
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Empty(t, added)
+	assert.Len(t, removed, 2, "Expected the removed directory and its subdirectory")
+	assert.Equal(t, int64(10), root.Size.Size)
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
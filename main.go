@@ -1,13 +1,33 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/StevenCyb/MemSpace/internal/chunk"
 	"github.com/StevenCyb/MemSpace/internal/cli"
+	"github.com/StevenCyb/MemSpace/internal/dedup"
+	"github.com/StevenCyb/MemSpace/internal/diff"
+	"github.com/StevenCyb/MemSpace/internal/dupes"
+	"github.com/StevenCyb/MemSpace/internal/empty"
+	"github.com/StevenCyb/MemSpace/internal/export"
+	"github.com/StevenCyb/MemSpace/internal/growth"
+	"github.com/StevenCyb/MemSpace/internal/histogram"
 	"github.com/StevenCyb/MemSpace/internal/models"
+	"github.com/StevenCyb/MemSpace/internal/ncdu"
+	"github.com/StevenCyb/MemSpace/internal/order"
 	"github.com/StevenCyb/MemSpace/internal/print"
+	"github.com/StevenCyb/MemSpace/internal/remove"
+	"github.com/StevenCyb/MemSpace/internal/snapshot"
+	"github.com/StevenCyb/MemSpace/internal/top"
+	"github.com/StevenCyb/MemSpace/internal/tui"
+	"github.com/StevenCyb/MemSpace/internal/unit"
 	"github.com/StevenCyb/MemSpace/internal/utils"
+	"github.com/StevenCyb/MemSpace/internal/watch"
 
 	"github.com/fatih/color"
 	"github.com/jessevdk/go-flags"
@@ -27,12 +47,505 @@ func main() {
 		print.SystemMemory(arguments.BasePath)
 	}
 
+	if arguments.Command == cli.CommandDiff {
+		runDiff(arguments)
+		return
+	}
+
+	if arguments.Command == cli.CommandUndo {
+		actions, err := dedup.Undo(arguments.Undo.Journal, arguments.Undo.DryRun)
+		print.Dedup(actions, arguments.Undo.DryRun, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error undoing the journal: %s\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	streamable := arguments.Command == "" && arguments.Save == "" && arguments.HTML == "" && arguments.SVG == "" &&
+		arguments.Cache == "" && arguments.Import == ""
+
+	if arguments.Top != "" && streamable {
+		runTop(arguments)
+		return
+	}
+
+	if arguments.Format == "ndjson" && streamable && !arguments.Growth && !arguments.Watch {
+		runNDJSON(arguments)
+		return
+	}
+
+	if arguments.DU != "" && streamable {
+		runDU(arguments, nil)
+		return
+	}
+
+	root, err := scan(arguments)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error walking the path: %s\n"), err)
+		return
+	}
+
+	if arguments.Save != "" {
+		if err := snapshot.Save(arguments.Save, arguments.BasePath, root); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("failed to save snapshot: %s\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	if arguments.HTML != "" {
+		if err := writeHTML(arguments, root); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("failed to write HTML report: %s\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	if arguments.SVG != "" {
+		if err := writeSVG(arguments, root); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("failed to write SVG image: %s\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	if arguments.Command == cli.CommandDupes {
+		var minSize int64
+		if arguments.Threshold != nil {
+			minSize = arguments.Threshold.Size
+		}
+
+		sets, err := dupes.Find(root, minSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error searching duplicates: %s\n"), err)
+			os.Exit(1)
+		}
+
+		print.Duplicates(sets)
+		if arguments.Dupes.Link != "" {
+			runDedup(arguments.Dupes, sets)
+		}
+		return
+	}
+
+	if arguments.Command == cli.CommandEmpty {
+		result := empty.Find(root)
+		if !arguments.Empty.Remove {
+			print.Empty(result)
+			return
+		}
+
+		removals, err := empty.Remove(result, arguments.Empty.DryRun)
+		print.Removals(removals, arguments.Empty.DryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error removing empty items: %s\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if arguments.Command == cli.CommandHistogram {
+		histograms := []*histogram.Histogram{histogram.FromTree(root)}
+		if arguments.Histogram.PerDirectory {
+			for _, child := range root.Children {
+				if child.ItemType == models.ItemTypeDirectory {
+					histograms = append(histograms, histogram.FromTree(child))
+				}
+			}
+		}
+
+		if arguments.Histogram.Format == "tsv" {
+			print.HistogramTSV(histograms)
+			return
+		}
+
+		for i, h := range histograms {
+			if i > 0 {
+				fmt.Println()
+			}
+			print.Histogram(h)
+		}
+		return
+	}
+
+	if arguments.Command == cli.CommandEstimate {
+		report, err := chunk.Estimate(root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error estimating deduplication: %s\n"), err)
+			os.Exit(1)
+		}
+
+		print.Estimate(report)
+		return
+	}
+
+	if arguments.Command == cli.CommandRemove {
+		runRemove(arguments, root)
+		return
+	}
+
+	if arguments.Top != "" {
+		ranking := top.New(arguments.Limit)
+		ranking.AddTree(root)
+		print.Top(ranking, arguments.Top)
+		return
+	}
+
+	if arguments.Browse {
+		if err := tui.Run(root); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error browsing the tree: %s\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if arguments.Growth {
+		runGrowth(arguments, root)
+		return
+	}
+
+	if arguments.Watch {
+		err := watch.Run(root, arguments.Interval, func(stats watch.Stats) {
+			print.ClearScreen()
+			printTree(arguments, root, 2)
+			print.WatchStatus(stats, arguments.Interval)
+		})
+		fmt.Fprintf(os.Stderr, color.RedString("error watching the path: %s\n"), err)
+		os.Exit(1)
+	}
+
+	if arguments.DU != "" {
+		runDU(arguments, root)
+		return
+	}
+
+	if arguments.Format != "tree" {
+		runExport(arguments, root)
+		return
+	}
+
+	printTree(arguments, root, 1)
+}
+
+// printTree prints the tree or, if requested, the treemap, which leaves reserved rows of the terminal free.
+func printTree(arguments *cli.Arguments, root *models.Item, reserved int) {
+	if !arguments.Treemap {
+		opts := print.TreeOptions{
+			Recursive:     arguments.Recursive,
+			DirectoryOnly: arguments.DirectoryOnly,
+			Depth:         arguments.Depth,
+			Threshold:     arguments.Threshold,
+			Percent:       arguments.Percent,
+			Bar:           arguments.Bar,
+			MaxChildren:   arguments.MaxChildren,
+			MinPercent:    arguments.MinPercent,
+		}
+		if arguments.Sort != "" {
+			opts.Sort = order.New(order.Mode(arguments.Sort), arguments.Order, arguments.Natural)
+		}
+		print.Tree(root, opts)
+		return
+	}
+
+	width, height, ok := utils.TerminalSize()
+	if !ok {
+		width, height = 80, 24
+	}
+	print.Treemap(root, arguments.DirectoryOnly, arguments.Depth, arguments.Threshold, width, height-1-reserved)
+}
+
+func scan(arguments *cli.Arguments) (*models.Item, error) {
+	if arguments.Import != "" {
+		return ncdu.Load(arguments.Import)
+	}
+
+	var cached *models.Item
+	if arguments.Cache != "" {
+		var err error
+		if cached, err = snapshot.Load(arguments.Cache); err != nil {
+			return nil, fmt.Errorf("failed to load cache: %w", err)
+		}
+	}
+
 	root := models.NewItem(utils.GetName(arguments.BasePath), arguments.BasePath, models.ItemTypeDirectory)
 	root.Root = true
-	if _, err := utils.WalkAndCollect(root, arguments.BasePath, 0); err != nil {
+	stats := &utils.RescanStats{}
+	if _, err := utils.Rescan(root, arguments.BasePath, cached, stats); err != nil {
+		return nil, err
+	}
+
+	if cached != nil {
+		fmt.Fprintf(os.Stderr, "Reused %s directories, rescanned %s directories\n",
+			color.GreenString("%d", stats.Reused), color.YellowString("%d", stats.Rescanned))
+	}
+
+	return root, nil
+}
+
+// runTop ranks the entries while scanning, without building the tree.
+func runTop(arguments *cli.Arguments) {
+	ranking := top.New(arguments.Limit)
+	_, err := utils.Stream(arguments.BasePath, func(entry utils.Entry) error {
+		if entry.Depth > 0 {
+			ranking.Add(top.Entry{Path: entry.Path, ItemType: entry.ItemType, Size: entry.Size.Size})
+		}
+		return nil
+	})
+	if err != nil {
 		fmt.Fprintf(os.Stderr, color.RedString("error walking the path: %s\n"), err)
+		os.Exit(1)
+	}
+
+	print.Top(ranking, arguments.Top)
+}
+
+// runNDJSON writes every entry as soon as it is scanned, without building the tree.
+func runNDJSON(arguments *cli.Arguments) {
+	out := bufio.NewWriter(os.Stdout)
+	_, err := utils.Stream(arguments.BasePath, export.NewNDJSON(out, exportOptions(arguments)).Write)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error walking the path: %s\n"), err)
+		os.Exit(1)
+	}
+}
+
+// runDU prints du-compatible lines, while scanning if root is nil or for the scanned tree otherwise.
+func runDU(arguments *cli.Arguments, root *models.Item) {
+	opts := exportOptions(arguments)
+	if arguments.MaxDepth != nil {
+		depth := *arguments.MaxDepth - 1
+		opts.Depth = &depth
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	du := export.NewDU(out, export.DUUnit(arguments.DU), opts)
+
+	var err error
+	if root == nil {
+		_, err = utils.Stream(arguments.BasePath, du.Write)
+	} else {
+		err = du.WriteTree(root)
+	}
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error writing du output: %s\n"), err)
+		os.Exit(1)
+	}
+}
+
+// runExport writes the scanned tree in the selected export format.
+func runExport(arguments *cli.Arguments, root *models.Item) {
+	out := bufio.NewWriter(os.Stdout)
+	opts := exportOptions(arguments)
+
+	var err error
+	switch arguments.Format {
+	case "json":
+		err = export.JSON(out, root, opts)
+	case "ndjson":
+		err = export.NewNDJSON(out, opts).WriteTree(root)
+	case "csv":
+		err = export.CSV(out, root, opts)
+	case "tsv":
+		err = export.TSV(out, root, opts)
+	case "ncdu":
+		err = ncdu.Write(out, root)
+	case "folded":
+		err = export.Folded(out, root, opts)
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("failed to write %s: %s\n"), arguments.Format, err)
+		os.Exit(1)
+	}
+}
+
+func writeHTML(arguments *cli.Arguments, root *models.Item) error {
+	file, err := os.Create(arguments.HTML)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	out := bufio.NewWriter(file)
+	if err := export.HTML(out, root, exportOptions(arguments)); err != nil {
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+func writeSVG(arguments *cli.Arguments, root *models.Item) error {
+	file, err := os.Create(arguments.SVG)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	svg := export.SVGOptions{
+		Chart:  export.SVGChart(arguments.SVGChart),
+		Color:  export.SVGColor(arguments.SVGColor),
+		Width:  1200,
+		Height: 800,
+	}
+	if err := export.SVG(file, root, exportOptions(arguments), svg); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+func exportOptions(arguments *cli.Arguments) export.Options {
+	return export.Options{DirectoryOnly: arguments.DirectoryOnly, Depth: arguments.Depth, Threshold: arguments.Threshold}
+}
+
+// runRemove deletes or trashes the requested items after a confirmation and prints the reclaimed size.
+func runRemove(arguments *cli.Arguments, root *models.Item) {
+	method := remove.MethodDelete
+	if arguments.Remove.Trash {
+		method = remove.MethodTrash
+	}
+
+	paths := make([]string, 0, len(arguments.Remove.Paths))
+	for _, path := range arguments.Remove.Paths {
+		paths = append(paths, treePath(arguments.BasePath, path))
+	}
+
+	plan, err := remove.Apply(root, paths, method, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error removing items: %s\n"), err)
+		os.Exit(1)
+	}
+
+	if arguments.Remove.DryRun {
+		print.Removed(plan, true)
 		return
 	}
 
-	print.Tree(root, arguments.Recursive, arguments.DirectoryOnly, arguments.Depth, arguments.Threshold, 0)
+	count := 0
+	for _, action := range plan {
+		if action.Skipped == "" {
+			count++
+		}
+	}
+
+	if count > 0 && !arguments.Remove.Yes {
+		fmt.Printf("%s %d items (%s)? [y/N] ", strings.ToUpper(string(method[:1]))+string(method[1:]), count,
+			color.YellowString(unit.NewFromBytes(remove.Reclaimed(plan)).RawSizeString()))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			fmt.Println("Aborted")
+			return
+		}
+	}
+
+	actions, err := remove.Apply(root, paths, method, false)
+	print.Removed(actions, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error removing items: %s\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s is now %s\n", color.BlueString(root.Path), color.YellowString(root.Size.RawSizeString()))
+}
+
+// treePath converts a path given on the command line into the form stored in the tree
+// scanned from basePath, so absolute and relative paths can be mixed.
+func treePath(basePath, path string) string {
+	absBase, errBase := filepath.Abs(basePath)
+	absPath, errPath := filepath.Abs(path)
+	if errBase != nil || errPath != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+
+	return filepath.Join(basePath, rel)
+}
+
+func runGrowth(arguments *cli.Arguments, before *models.Item) {
+	last := time.Now()
+	for {
+		time.Sleep(time.Until(last.Add(arguments.Interval)))
+
+		now := time.Now()
+		after, err := scan(arguments)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error walking the path: %s\n"), err)
+			os.Exit(1)
+		}
+
+		print.ClearScreen()
+		print.Growth(growth.Rates(before, after, now.Sub(last)), arguments.Limit, arguments.DirectoryOnly, now.Sub(last))
+
+		before, last = after, now
+	}
+}
+
+func runDedup(arguments *cli.DupesArguments, sets []dupes.Set) {
+	opts := dedup.Options{
+		Method:   dedup.Method(arguments.Link),
+		Metadata: dedup.Metadata(arguments.Metadata),
+		DryRun:   arguments.DryRun,
+	}
+
+	if !arguments.DryRun {
+		journal, err := os.OpenFile(arguments.Journal, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("failed to open journal: %s\n"), err)
+			os.Exit(1)
+		}
+		defer journal.Close()
+		opts.Journal = journal
+	}
+
+	actions, err := dedup.Apply(sets, opts)
+	print.Dedup(actions, arguments.DryRun, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("error replacing duplicates: %s\n"), err)
+		os.Exit(1)
+	}
+}
+
+func runDiff(arguments *cli.Arguments) {
+	before, err := snapshot.Load(arguments.Diff.Old)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("failed to load snapshot: %s\n"), err)
+		os.Exit(1)
+	}
+
+	var after *models.Item
+	if arguments.Diff.New != "" {
+		after, err = snapshot.Load(arguments.Diff.New)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("failed to load snapshot: %s\n"), err)
+			os.Exit(1)
+		}
+	} else {
+		after, err = scan(arguments)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("error walking the path: %s\n"), err)
+			return
+		}
+
+		if arguments.Save != "" {
+			if err := snapshot.Save(arguments.Save, arguments.BasePath, after); err != nil {
+				fmt.Fprintf(os.Stderr, color.RedString("failed to save snapshot: %s\n"), err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	print.Diff(diff.Compare(before, after), arguments.Recursive, arguments.DirectoryOnly, arguments.Depth, arguments.Threshold, 0)
 }